@@ -0,0 +1,294 @@
+package ion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/JupiterMetaLabs/ion/internal/core"
+)
+
+// AdminHandler returns an http.Handler for inspecting and changing a running
+// Ion instance without a restart. Routes are matched on the path suffix, so
+// the handler can be mounted under any prefix on any mux:
+//
+//	GET  <prefix>/levels   global, per-output, and per-component levels
+//	PUT  <prefix>/levels   change any of them (partial JSON body, see below)
+//	GET  <prefix>/status   enabled signals, init warnings, exporter health
+//
+// A PUT body sets only the keys it contains. An empty string unpins an output
+// or removes a component override:
+//
+//	{"level": "debug", "outputs": {"otel": "warn", "console": ""}, "components": {"p2p": "debug"}}
+//
+// The handler is unauthenticated unless [WithAdminAuth] is given; do not
+// expose it on a public listener without one.
+//
+// Example:
+//
+//	mux.Handle("/debug/ion/", ion.AdminHandler(app, ion.WithAdminAuth(func(r *http.Request) bool {
+//	    return r.Header.Get("Authorization") == "Bearer "+adminToken
+//	})))
+func AdminHandler(app *Ion, opts ...AdminOption) http.Handler {
+	o := &adminOptions{}
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+	return &adminHandler{app: app, auth: o.auth}
+}
+
+// AdminOption configures [AdminHandler].
+type AdminOption interface {
+	apply(*adminOptions)
+}
+
+type adminOptions struct {
+	auth func(r *http.Request) bool
+}
+
+type adminAuthOption func(r *http.Request) bool
+
+func (a adminAuthOption) apply(o *adminOptions) { o.auth = a }
+
+// WithAdminAuth protects the admin handler. Requests for which auth returns
+// false are rejected with 401 Unauthorized.
+func WithAdminAuth(auth func(r *http.Request) bool) AdminOption {
+	return adminAuthOption(auth)
+}
+
+type adminHandler struct {
+	app  *Ion
+	auth func(r *http.Request) bool
+}
+
+// --- JSON views ---
+
+type outputLevelView struct {
+	Level  string `json:"level"`
+	Pinned bool   `json:"pinned"` // false: follows the global and component levels
+}
+
+type levelsView struct {
+	Level      string                     `json:"level"`
+	Outputs    map[string]outputLevelView `json:"outputs"`
+	Components map[string]string          `json:"components"`
+}
+
+type levelsUpdate struct {
+	Level      *string           `json:"level"`
+	Outputs    map[string]string `json:"outputs"`
+	Components map[string]string `json:"components"`
+}
+
+type signalView struct {
+	Enabled      bool                    `json:"enabled"`
+	Healthy      bool                    `json:"healthy"`
+	Export       *core.HealthSnapshot    `json:"export,omitempty"`
+	TailSampling *core.TailSamplingStats `json:"tail_sampling,omitempty"`
+	Queue        *core.QueueStats        `json:"queue,omitempty"`
+}
+
+type warningView struct {
+	Component string `json:"component"`
+	Error     string `json:"error"`
+}
+
+type statusView struct {
+	Service  string                `json:"service"`
+	Version  string                `json:"version,omitempty"`
+	Signals  map[string]signalView `json:"signals"`
+	Warnings []warningView         `json:"warnings"`
+}
+
+// --- Routing ---
+
+func (h *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.auth != nil && !h.auth(r) {
+		writeAdminError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case strings.HasSuffix(path, "/levels"):
+		switch r.Method {
+		case http.MethodGet:
+			writeAdminJSON(w, http.StatusOK, h.levels())
+		case http.MethodPut:
+			h.putLevels(w, r)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeAdminError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	case strings.HasSuffix(path, "/status"):
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			writeAdminError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		writeAdminJSON(w, http.StatusOK, h.status())
+	default:
+		writeAdminError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (h *adminHandler) levels() levelsView {
+	l := h.app.zapLogger
+	view := levelsView{
+		Level:      l.GetLevel(),
+		Outputs:    map[string]outputLevelView{},
+		Components: map[string]string{},
+	}
+	if l.levels == nil {
+		return view
+	}
+	for _, sink := range l.levels.Sinks() {
+		lvl, pinned := sink.Level()
+		ov := outputLevelView{Level: view.Level, Pinned: pinned}
+		if pinned {
+			ov.Level = lvl.String()
+		}
+		view.Outputs[sink.Name()] = ov
+	}
+	for name, lvl := range l.levels.Components() {
+		view.Components[name] = lvl.String()
+	}
+	return view
+}
+
+func (h *adminHandler) putLevels(w http.ResponseWriter, r *http.Request) {
+	var req levelsUpdate
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Sprintf("invalid body: %v", err))
+		return
+	}
+
+	// Validate everything first so a bad entry leaves all levels untouched.
+	if err := h.validate(req); err != nil {
+		writeAdminError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	l := h.app.zapLogger
+	if req.Level != nil {
+		lvl, _ := core.ParseLevel(*req.Level)
+		l.atomicLvl.SetLevel(lvl)
+	}
+	for _, name := range sortedKeys(req.Outputs) {
+		_ = l.SetOutputLevel(name, req.Outputs[name])
+	}
+	for _, name := range sortedKeys(req.Components) {
+		_ = l.SetLevelFor(name, req.Components[name])
+	}
+
+	writeAdminJSON(w, http.StatusOK, h.levels())
+}
+
+func (h *adminHandler) validate(req levelsUpdate) error {
+	l := h.app.zapLogger
+	if req.Level != nil {
+		if _, err := core.ParseLevel(*req.Level); err != nil {
+			return fmt.Errorf("level: %w", err)
+		}
+	}
+	if (len(req.Outputs) > 0 || len(req.Components) > 0) && l.levels == nil {
+		return errors.New("per-output and per-component levels are not available on this logger")
+	}
+	for name, level := range req.Outputs {
+		if l.levels.Sink(name) == nil {
+			return fmt.Errorf("output %q is not enabled", name)
+		}
+		if level == "" {
+			continue
+		}
+		if _, err := core.ParseLevel(level); err != nil {
+			return fmt.Errorf("output %q: %w", name, err)
+		}
+	}
+	for name, level := range req.Components {
+		if name == "" {
+			return errors.New("component name must not be empty")
+		}
+		if level == "" {
+			continue
+		}
+		if _, err := core.ParseLevel(level); err != nil {
+			return fmt.Errorf("component %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (h *adminHandler) status() statusView {
+	app := h.app
+	var logsHealth *core.ExportHealth
+	var logsQueue *core.ExportQueue
+	if app.zapLogger != nil {
+		logsHealth = app.otelProvider.Health()
+		logsQueue = app.otelProvider.Queue()
+	}
+
+	view := statusView{
+		Service: app.serviceName,
+		Version: app.version,
+		Signals: map[string]signalView{
+			"logs":    newSignalView(logsHealth != nil, logsHealth, logsQueue),
+			"tracing": newSignalView(app.tracingEnabled, app.tracerProvider.Health(), app.tracerProvider.Queue()),
+			"metrics": newSignalView(app.metricsEnabled, app.meterProvider.Health(), app.meterProvider.Queue()),
+		},
+		Warnings: make([]warningView, 0, len(app.warnings)),
+	}
+	if tail := app.tracerProvider.TailSampler(); tail != nil {
+		tracing := view.Signals["tracing"]
+		stats := tail.Stats()
+		tracing.TailSampling = &stats
+		view.Signals["tracing"] = tracing
+	}
+	for _, w := range app.warnings {
+		view.Warnings = append(view.Warnings, warningView{Component: w.Component, Error: w.Err.Error()})
+	}
+	return view
+}
+
+func newSignalView(enabled bool, health *core.ExportHealth, queue *core.ExportQueue) signalView {
+	v := signalView{Enabled: enabled}
+	if !enabled || health == nil {
+		return v
+	}
+	snap := health.Snapshot()
+	v.Healthy = snap.Healthy()
+	v.Export = &snap
+	if queue != nil {
+		stats := queue.Stats()
+		v.Queue = &stats
+	}
+	return v
+}
+
+// --- Helpers ---
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeAdminJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeAdminError(w http.ResponseWriter, status int, msg string) {
+	writeAdminJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,153 @@
+package ion
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func adminRequest(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAdminHandler_Levels(t *testing.T) {
+	app, _, err := New(Default())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/debug/ion/", AdminHandler(app))
+
+	rec := adminRequest(t, mux, http.MethodGet, "/debug/ion/levels", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET levels status = %d, want 200", rec.Code)
+	}
+	var got levelsView
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Level != "info" {
+		t.Errorf("level = %q, want \"info\"", got.Level)
+	}
+	if out, ok := got.Outputs["console"]; !ok || out.Pinned {
+		t.Errorf("console output = %+v (present %v), want unpinned", out, ok)
+	}
+
+	body := `{"level":"warn","outputs":{"console":"debug"},"components":{"p2p":"error"}}`
+	rec = adminRequest(t, mux, http.MethodPut, "/debug/ion/levels", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT levels status = %d, body %s", rec.Code, rec.Body)
+	}
+	if got := app.GetLevel(); got != "warn" {
+		t.Errorf("global level = %q, want \"warn\"", got)
+	}
+	if got := app.LevelFor("p2p"); got != "error" {
+		t.Errorf("LevelFor(p2p) = %q, want \"error\"", got)
+	}
+	lvl, pinned := app.levels.Sink("console").Level()
+	if !pinned || lvl.String() != "debug" {
+		t.Errorf("console = %v (pinned %v), want debug pinned", lvl, pinned)
+	}
+
+	// Empty string unpins the output.
+	rec = adminRequest(t, mux, http.MethodPut, "/debug/ion/levels", `{"outputs":{"console":""}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT unpin status = %d, body %s", rec.Code, rec.Body)
+	}
+	if _, pinned := app.levels.Sink("console").Level(); pinned {
+		t.Error("console should follow the global level after unpinning")
+	}
+}
+
+func TestAdminHandler_LevelsInvalid(t *testing.T) {
+	app, _, _ := New(Default())
+	h := AdminHandler(app)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"bad global", `{"level":"loud"}`},
+		{"disabled output", `{"outputs":{"file":"debug"}}`},
+		{"bad output level", `{"outputs":{"console":"loud"}}`},
+		{"bad component level", `{"components":{"p2p":"loud"}}`},
+		{"valid global with bad entry", `{"level":"debug","components":{"p2p":"loud"}}`},
+		{"unknown key", `{"lvl":"debug"}`},
+		{"not json", `debug`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Nothing is applied when any entry is invalid.
+			rec := adminRequest(t, h, http.MethodPut, "/levels", tt.body)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", rec.Code)
+			}
+			if got := app.GetLevel(); got != "info" {
+				t.Errorf("global level = %q, want unchanged \"info\"", got)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_Status(t *testing.T) {
+	app, _, _ := New(Default())
+	app.warnings = []Warning{{Component: "tracing", Err: errors.New("collector unreachable")}}
+	h := AdminHandler(app)
+
+	rec := adminRequest(t, h, http.MethodGet, "/admin/status", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var got statusView
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	for _, signal := range []string{"logs", "tracing", "metrics"} {
+		if got.Signals[signal].Enabled {
+			t.Errorf("%s enabled = true, want false", signal)
+		}
+	}
+	if len(got.Warnings) != 1 || got.Warnings[0].Component != "tracing" {
+		t.Errorf("warnings = %+v, want one tracing warning", got.Warnings)
+	}
+}
+
+func TestAdminHandler_Routing(t *testing.T) {
+	app, _, _ := New(Default())
+	h := AdminHandler(app, WithAdminAuth(func(r *http.Request) bool {
+		return r.Header.Get("Authorization") == "Bearer secret"
+	}))
+
+	rec := adminRequest(t, h, http.MethodGet, "/levels", "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("unauthenticated status = %d, want 401", rec.Code)
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/levels", http.StatusOK},
+		{http.MethodGet, "/levels/", http.StatusOK},
+		{http.MethodDelete, "/levels", http.StatusMethodNotAllowed},
+		{http.MethodPut, "/status", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/nope", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, rec.Code, tt.want)
+		}
+	}
+}
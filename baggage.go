@@ -0,0 +1,69 @@
+package ion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// ContextWithBaggage returns a copy of ctx whose W3C baggage also holds
+// fields, each as a member named after the field key with the value in its
+// printed form. Baggage travels to downstream services with the "baggage"
+// propagator; members listed in Tracing.BaggageToLogs are added to log
+// entries and spans on both sides.
+//
+//	ctx, err := ion.ContextWithBaggage(ctx, ion.String("tenant", "acme"), fields.ChainID("mainnet"))
+//
+// It returns ctx unchanged and an error if a key is empty or not valid UTF-8.
+func ContextWithBaggage(ctx context.Context, fields ...Field) (context.Context, error) {
+	bag := baggage.FromContext(ctx)
+	for _, f := range fields {
+		m, err := baggage.NewMemberRaw(f.Key, fieldString(f))
+		if err != nil {
+			return ctx, fmt.Errorf("baggage member %q: %w", f.Key, err)
+		}
+		if bag, err = bag.SetMember(m); err != nil {
+			return ctx, fmt.Errorf("baggage member %q: %w", f.Key, err)
+		}
+	}
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
+// baggageExtractor adds the baggage members named by keys to log entries as
+// string fields (Tracing.BaggageToLogs).
+func baggageExtractor(keys []string) ContextExtractor {
+	return func(ctx context.Context) []Field {
+		bag := baggage.FromContext(ctx)
+		if bag.Len() == 0 {
+			return nil
+		}
+		var fields []Field
+		for _, k := range keys {
+			if m := bag.Member(k); m.Key() != "" {
+				fields = append(fields, String(k, m.Value()))
+			}
+		}
+		return fields
+	}
+}
+
+// fieldString returns the printed form of f's value.
+func fieldString(f Field) string {
+	switch f.Type {
+	case StringType:
+		return f.StringVal
+	case Int64Type:
+		return strconv.FormatInt(f.Integer, 10)
+	case Float64Type:
+		return strconv.FormatFloat(f.Float, 'g', -1, 64)
+	case BoolType:
+		return strconv.FormatBool(f.Integer == 1)
+	case ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return err.Error()
+		}
+	}
+	return fmt.Sprint(f.Interface)
+}
@@ -0,0 +1,71 @@
+package ion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestContextWithBaggage(t *testing.T) {
+	ctx, err := ContextWithBaggage(context.Background(), String("tenant", "acme"))
+	if err != nil {
+		t.Fatalf("ContextWithBaggage() error: %v", err)
+	}
+	ctx, err = ContextWithBaggage(ctx, Uint64("shard", 3), Bool("canary", true), Err(errors.New("a b")))
+	if err != nil {
+		t.Fatalf("ContextWithBaggage() error: %v", err)
+	}
+
+	bag := baggage.FromContext(ctx)
+	for key, want := range map[string]string{"tenant": "acme", "shard": "3", "canary": "true", "error": "a b"} {
+		if got := bag.Member(key).Value(); got != want {
+			t.Errorf("baggage %s = %q, want %q", key, got, want)
+		}
+	}
+
+	if _, err := ContextWithBaggage(ctx, String("", "v")); err == nil {
+		t.Error("ContextWithBaggage() with an invalid key: want error")
+	}
+}
+
+func TestBaggageToLogs(t *testing.T) {
+	obsCore, logs := observer.New(zapcore.DebugLevel)
+	spans := tracetest.NewSpanRecorder()
+	cfg := Default()
+	cfg.Console.Enabled = false
+	cfg.Tracing.Sampler = "always"
+	cfg.Tracing.BaggageToLogs = []string{"tenant"}
+	app, _, err := New(cfg, WithZapCore(obsCore), WithSpanProcessor(spans))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = app.Shutdown(context.Background()) }()
+
+	ctx, _ := ContextWithBaggage(context.Background(), String("tenant", "acme"), String("session", "s3cr3t"))
+	ctx, span := app.Tracer("test").Start(ctx, "handle")
+	app.Info(ctx, "handled")
+	span.End()
+
+	fields := logs.All()[0].ContextMap()
+	if fields["tenant"] != "acme" {
+		t.Errorf("log tenant = %v, want acme", fields["tenant"])
+	}
+	if _, ok := fields["session"]; ok {
+		t.Error("logged session, which is not allow-listed")
+	}
+
+	var got string
+	for _, kv := range spans.Ended()[0].Attributes() {
+		if kv.Key == "tenant" {
+			got = kv.Value.AsString()
+		}
+	}
+	if got != "acme" {
+		t.Errorf("span tenant = %q, want acme", got)
+	}
+}
@@ -17,12 +17,33 @@ type FileConfig = config.FileConfig
 // OTELConfig configures OTEL log export.
 type OTELConfig = config.OTELConfig
 
+// QueueConfig configures the disk-backed queue of an OTLP exporter.
+type QueueConfig = config.QueueConfig
+
 // TracingConfig configures distributed tracing.
 type TracingConfig = config.TracingConfig
 
+// SamplerRule selects a trace sampler for spans by name or attribute.
+type SamplerRule = config.SamplerRule
+
+// TailSamplingConfig configures in-process tail-based trace sampling.
+type TailSamplingConfig = config.TailSamplingConfig
+
+// LogsAsEventsConfig configures recording log entries as span events.
+type LogsAsEventsConfig = config.LogsAsEventsConfig
+
 // MetricsConfig configures OpenTelemetry metrics export.
 type MetricsConfig = config.MetricsConfig
 
+// RedactionConfig configures scrubbing of sensitive log fields.
+type RedactionConfig = config.RedactionConfig
+
+// SamplingConfig configures log sampling.
+type SamplingConfig = config.SamplingConfig
+
+// SamplingRule is the per-interval sampling budget for one level.
+type SamplingRule = config.SamplingRule
+
 // Default returns a Config with sensible production defaults.
 func Default() Config {
 	return config.Default()
@@ -32,3 +53,31 @@ func Default() Config {
 func Development() Config {
 	return config.Development()
 }
+
+// LoadConfig reads a YAML or JSON configuration file and returns the resulting Config.
+//
+// Values are layered in order: [Default], then the file, then environment
+// variables named by the `env` struct tags (LOG_LEVEL, SERVICE_NAME,
+// OTEL_USERNAME, ...). The result is validated before it is returned.
+// Errors name the file and the offending key.
+//
+// Example config.yaml:
+//
+//	service_name: payment-node
+//	level: info
+//	otel:
+//	  enabled: true
+//	  endpoint: otel-collector:4317
+//	  timeout: 10s
+//	tracing:
+//	  enabled: true
+//	  sampler: ratio:0.1
+func LoadConfig(path string) (Config, error) {
+	return config.Load(path)
+}
+
+// ConfigFromEnv returns [Default] with environment variable overrides applied.
+// The result is validated before it is returned.
+func ConfigFromEnv() (Config, error) {
+	return config.FromEnv()
+}
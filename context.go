@@ -5,6 +5,8 @@ import (
 
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+
+	"github.com/JupiterMetaLabs/ion/internal/core"
 )
 
 // contextKey is an unexported type for context keys defined in this package.
@@ -18,14 +20,73 @@ const (
 	userIDKey    contextKey = "user_id"
 	traceIDKey   contextKey = "trace_id"
 	spanIDKey    contextKey = "span_id"
+	fieldsKey    contextKey = "fields"
 )
 
+// contextFields is the value stored under fieldsKey. The zap form is
+// converted once, when the fields are attached, rather than on every log call.
+type contextFields struct {
+	fields []Field
+	zap    []zap.Field
+}
+
+// ContextWithFields returns a copy of ctx carrying fields, which are added to
+// every log entry written with it or a context derived from it. Fields add
+// to those already in ctx; a field with the same key replaces the earlier
+// one in place. A per-call field with the same key as a context field wins.
+//
+//	ctx = ion.ContextWithFields(ctx, fields.BlockHeight(h), fields.PeerID(p))
+//	app.Info(ctx, "block applied") // carries block_height and peer_id
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	var parent []Field
+	if cf, ok := ctx.Value(fieldsKey).(*contextFields); ok {
+		parent = cf.fields
+	}
+
+	merged := make([]Field, 0, len(parent)+len(fields))
+	index := make(map[string]int, len(parent)+len(fields))
+	for _, f := range append(parent[:len(parent):len(parent)], fields...) {
+		if i, ok := index[f.Key]; ok {
+			merged[i] = f
+			continue
+		}
+		index[f.Key] = len(merged)
+		merged = append(merged, f)
+	}
+	return context.WithValue(ctx, fieldsKey, &contextFields{fields: merged, zap: toZapFields(merged)})
+}
+
+// FieldsFromContext returns the fields attached to ctx with
+// ContextWithFields, or nil.
+func FieldsFromContext(ctx context.Context) []Field {
+	if cf, ok := ctx.Value(fieldsKey).(*contextFields); ok {
+		return append([]Field(nil), cf.fields...)
+	}
+	return nil
+}
+
+// ContextExtractor returns fields to add to a log entry from its context,
+// e.g. values another package stores there. Register it with
+// [WithContextExtractor]. It is not called for context.Background() or
+// context.TODO().
+type ContextExtractor func(ctx context.Context) []Field
+
 // WithRequestID adds a request ID to the context.
 // This ID will be automatically included in logs.
 func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, requestIDKey, requestID)
 }
 
+// NewRequestID returns a new request ID: a 26-character ULID that sorts by
+// creation time. The ionhttp and iongrpc middleware use it for requests that
+// arrive without one.
+func NewRequestID() string {
+	return core.NewRequestID()
+}
+
 // WithUserID adds a user ID to the context.
 // This ID will be automatically included in logs as the "user_id" field.
 func WithUserID(ctx context.Context, userID string) context.Context {
@@ -70,7 +131,8 @@ func TraceIDFromContext(ctx context.Context) string {
 	return ""
 }
 
-// extractContextZapFields pulls trace/span IDs and custom values from context.
+// extractContextZapFields pulls trace/span IDs, request/user IDs, and fields
+// attached with ContextWithFields from context.
 // Returns zap.Field slice directly for use in log methods (avoids Field conversion).
 // Lazily allocates the slice only when fields are found.
 func extractContextZapFields(ctx context.Context) []zap.Field {
@@ -118,5 +180,10 @@ func extractContextZapFields(ctx context.Context) []zap.Field {
 		fields = append(fields, zap.String("user_id", userID))
 	}
 
+	// Fields attached with ContextWithFields
+	if cf, ok := ctx.Value(fieldsKey).(*contextFields); ok {
+		fields = append(fields, cf.zap...)
+	}
+
 	return fields
 }
@@ -0,0 +1,175 @@
+package ion
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/JupiterMetaLabs/ion/internal/core"
+)
+
+// Go runs fn in a new goroutine named name, the safe way to continue work
+// after a request returns:
+//
+//   - fn's context keeps the values of ctx (request_id, ContextWithFields
+//     fields, baggage) but is not cancelled with it.
+//   - fn runs in a new root span named name, linked to the span in ctx, so
+//     its trace is complete even when the caller's ends first.
+//   - A panic in fn is recovered and logged as Critical with the stack, and
+//     recorded on the span. An error returned by fn is logged as Error and
+//     recorded on the span.
+//   - Exits are counted in ion.goroutine.exits and failures (errors and
+//     panics) in ion.goroutine.failures, with a "goroutine" attribute set to
+//     name. Keep names static to bound cardinality.
+//
+// Example:
+//
+//	ion.Go(ctx, app, "send-receipt", func(ctx context.Context) error {
+//	    return mailer.Send(ctx, receipt)
+//	})
+func Go(ctx context.Context, app *Ion, name string, fn func(ctx context.Context) error) {
+	ctx, link := detach(ctx)
+	go func() { _ = runLinked(ctx, link, app, name, fn, true) }()
+}
+
+// Group runs goroutines like [Go] and waits for them, like errgroup. The
+// goroutines share a context detached from the one passed to [NewGroup]; it
+// is cancelled when the first of them fails or when Wait returns.
+//
+//	g := ion.NewGroup(ctx, app)
+//	for _, peer := range peers {
+//	    g.Go("announce", func(ctx context.Context) error { return peer.Announce(ctx, block) })
+//	}
+//	err := g.Wait()
+type Group struct {
+	app    *Ion
+	ctx    context.Context
+	link   trace.Link
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+// NewGroup returns a Group whose goroutines log, trace, and measure through
+// app and are linked to the span in ctx.
+func NewGroup(ctx context.Context, app *Ion) *Group {
+	ctx, link := detach(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{app: app, ctx: ctx, link: link, cancel: cancel}
+}
+
+// Go runs fn in a new goroutine named name, as [Go] does. Errors are not
+// logged (Wait returns the first one) but panics are, and are returned by
+// Wait as errors.
+func (g *Group) Go(name string, fn func(ctx context.Context) error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := runLinked(g.ctx, g.link, g.app, name, fn, false); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				g.cancel(err)
+			})
+		}
+	}()
+}
+
+// Wait blocks until all goroutines started with Go have returned, then
+// returns the first error, if any.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(context.Canceled)
+	return g.err
+}
+
+// detach returns ctx without its cancellation and a link to its span.
+func detach(ctx context.Context) (context.Context, trace.Link) {
+	return context.WithoutCancel(ctx), trace.LinkFromContext(ctx)
+}
+
+// panicError is returned by runLinked for a recovered panic.
+type panicError struct {
+	name  string
+	value any
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("goroutine %s panicked: %v", e.name, e.value)
+}
+
+// runLinked runs fn in a new root span linked to link, recovering panics and
+// counting the exit. With logErrors, an error returned by fn is logged too.
+func runLinked(ctx context.Context, link trace.Link, app *Ion, name string, fn func(ctx context.Context) error, logErrors bool) (err error) {
+	ctx, span := app.TracerProvider().Tracer(core.InstrumentationName).Start(ctx, name,
+		trace.WithNewRoot(),
+		trace.WithLinks(link),
+	)
+	defer func() {
+		r := recover()
+		switch {
+		case r != nil:
+			stack := string(debug.Stack())
+			err = &panicError{name: name, value: r}
+			app.Critical(ctx, "goroutine panicked", err, String("goroutine", name), String("stack", stack))
+			span.RecordError(err, trace.WithAttributes(attribute.String("exception.stacktrace", stack)))
+		case err != nil:
+			if logErrors {
+				app.Error(ctx, "goroutine failed", err, String("goroutine", name))
+			}
+			span.RecordError(err)
+		}
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		countExit(ctx, app, name, err != nil, r != nil)
+	}()
+	return fn(ctx)
+}
+
+// goroutineCounters holds an instance's goroutine counters, shared by its
+// children and created on the first exit.
+type goroutineCounters struct {
+	once     sync.Once
+	exits    metric.Int64Counter
+	failures metric.Int64Counter
+}
+
+// countExit records a goroutine exit, and a failure if it failed.
+func countExit(ctx context.Context, app *Ion, name string, failed, panicked bool) {
+	c := app.goroutines
+	if c == nil {
+		return
+	}
+	c.once.Do(func() {
+		meter := app.Meter(core.InstrumentationName)
+		var err error
+		c.exits, err = meter.Int64Counter("ion.goroutine.exits",
+			metric.WithUnit("{goroutine}"),
+			metric.WithDescription("Goroutines started with ion.Go or Group.Go that returned."))
+		if err != nil {
+			handleError(fmt.Errorf("failed to create goroutine exit counter: %w", err))
+		}
+		c.failures, err = meter.Int64Counter("ion.goroutine.failures",
+			metric.WithUnit("{goroutine}"),
+			metric.WithDescription("Goroutines started with ion.Go or Group.Go that returned an error or panicked."))
+		if err != nil {
+			handleError(fmt.Errorf("failed to create goroutine failure counter: %w", err))
+		}
+	})
+
+	goroutine := attribute.String("goroutine", name)
+	if c.exits != nil {
+		c.exits.Add(ctx, 1, metric.WithAttributes(goroutine))
+	}
+	if failed && c.failures != nil {
+		c.failures.Add(ctx, 1, metric.WithAttributes(goroutine, attribute.Bool("panic", panicked)))
+	}
+}
@@ -0,0 +1,220 @@
+package ion
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type goroutineHarness struct {
+	app    *Ion
+	logs   *observer.ObservedLogs
+	spans  *tracetest.SpanRecorder
+	reader *sdkmetric.ManualReader
+}
+
+func newGoroutineHarness(t *testing.T) *goroutineHarness {
+	t.Helper()
+	obsCore, logs := observer.New(zapcore.DebugLevel)
+	h := &goroutineHarness{logs: logs, spans: tracetest.NewSpanRecorder(), reader: sdkmetric.NewManualReader()}
+	cfg := Default()
+	cfg.Console.Enabled = false
+	cfg.Tracing.Sampler = "always"
+	app, _, err := New(cfg, WithZapCore(obsCore), WithSpanProcessor(h.spans), WithMetricReader(h.reader))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	t.Cleanup(func() { _ = app.Shutdown(context.Background()) })
+	h.app = app
+	return h
+}
+
+// count sums the counter name over points whose attributes include want.
+func (h *goroutineHarness) count(t *testing.T, name string, want ...attribute.KeyValue) int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := h.reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	var n int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if m.Name != name || !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				match := true
+				for _, kv := range want {
+					if v, ok := dp.Attributes.Value(kv.Key); !ok || v != kv.Value {
+						match = false
+					}
+				}
+				if match {
+					n += dp.Value
+				}
+			}
+		}
+	}
+	return n
+}
+
+func TestGo(t *testing.T) {
+	h := newGoroutineHarness(t)
+
+	parentCtx, cancel := context.WithCancel(ContextWithFields(WithRequestID(context.Background(), "req-1"), String("peer_id", "p1")))
+	parentCtx, parent := h.app.Tracer("test").Start(parentCtx, "handler")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	Go(parentCtx, h.app, "send-receipt", func(ctx context.Context) error {
+		defer wg.Done()
+		cancel()
+		parent.End()
+		if ctx.Err() != nil {
+			t.Error("goroutine context was cancelled with the caller's")
+		}
+		h.app.Info(ctx, "sending")
+		return errors.New("smtp down")
+	})
+	wg.Wait()
+
+	waitForGoroutines(t, h, 1)
+	span, caller := h.spans.Ended()[1], h.spans.Ended()[0]
+	if span.Name() != "send-receipt" {
+		t.Fatalf("span name = %q, want send-receipt", span.Name())
+	}
+	if span.Parent().IsValid() {
+		t.Error("goroutine span has a parent, want a new root")
+	}
+	if links := span.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != caller.SpanContext().SpanID() {
+		t.Errorf("links = %v, want one to the caller's span", links)
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want Error", span.Status().Code)
+	}
+
+	var sending, failed bool
+	for _, e := range h.logs.All() {
+		fields := e.ContextMap()
+		switch e.Message {
+		case "sending":
+			sending = fields["request_id"] == "req-1" && fields["peer_id"] == "p1" &&
+				fields["trace_id"] == span.SpanContext().TraceID().String()
+		case "goroutine failed":
+			failed = e.Level == zapcore.ErrorLevel && fields["goroutine"] == "send-receipt"
+		}
+	}
+	if !sending {
+		t.Error("log in goroutine is missing request_id, peer_id, or the goroutine's trace_id")
+	}
+	if !failed {
+		t.Error("returned error was not logged")
+	}
+
+	if n := h.count(t, "ion.goroutine.failures", attribute.String("goroutine", "send-receipt"), attribute.Bool("panic", false)); n != 1 {
+		t.Errorf("failures = %d, want 1", n)
+	}
+}
+
+func TestGo_Panic(t *testing.T) {
+	h := newGoroutineHarness(t)
+
+	Go(context.Background(), h.app, "reindex", func(ctx context.Context) error {
+		panic("index out of range")
+	})
+
+	waitForGoroutines(t, h, 1)
+	entries := h.logs.FilterMessage("goroutine panicked").All()
+	if len(entries) != 1 || entries[0].Level != zapcore.FatalLevel {
+		t.Fatalf("panic entries = %v, want one Critical", entries)
+	}
+	if stack, _ := entries[0].ContextMap()["stack"].(string); !strings.Contains(stack, "goroutine_test.go") {
+		t.Errorf("stack field does not point at the panic: %q", stack)
+	}
+	span := h.spans.Ended()[0]
+	if span.Status().Code != codes.Error || len(span.Events()) == 0 || span.Events()[0].Name != "exception" {
+		t.Errorf("span status %v, events %v; want Error with an exception event", span.Status().Code, span.Events())
+	}
+	if n := h.count(t, "ion.goroutine.failures", attribute.Bool("panic", true)); n != 1 {
+		t.Errorf("panic failures = %d, want 1", n)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	h := newGoroutineHarness(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g := NewGroup(ctx, h.app)
+	cancel()
+
+	g.Go("ok", func(ctx context.Context) error { return nil })
+	g.Go("fail", func(ctx context.Context) error { return errors.New("peer unreachable") })
+	g.Go("wait", func(ctx context.Context) error {
+		<-ctx.Done() // cancelled by the failure, not by the caller
+		return nil
+	})
+	g.Go("panic", func(ctx context.Context) error {
+		<-ctx.Done()
+		panic("boom")
+	})
+
+	err := g.Wait()
+	if err == nil || err.Error() != "peer unreachable" {
+		t.Errorf("Wait() = %v, want peer unreachable", err)
+	}
+	if n := h.logs.FilterMessage("goroutine failed").Len(); n != 0 {
+		t.Errorf("Group logged %d returned errors, want 0", n)
+	}
+	if n := h.logs.FilterMessage("goroutine panicked").Len(); n != 1 {
+		t.Errorf("Group logged %d panics, want 1", n)
+	}
+	if n := h.count(t, "ion.goroutine.exits"); n != 4 {
+		t.Errorf("exits = %d, want 4", n)
+	}
+	if n := h.count(t, "ion.goroutine.failures"); n != 2 {
+		t.Errorf("failures = %d, want 2", n)
+	}
+}
+
+// waitForGoroutines waits until n goroutines started with Go have exited.
+func waitForGoroutines(t *testing.T, h *goroutineHarness, n int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for h.count(t, "ion.goroutine.exits") < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d goroutine exits", n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestGo_CountersSharedWithChildren(t *testing.T) {
+	h := newGoroutineHarness(t)
+	child := h.app.Child("sync")
+	if child.goroutines != h.app.goroutines || child.Child("p2p").goroutines != h.app.goroutines {
+		t.Fatal("children should share the parent's goroutine counters")
+	}
+
+	Go(context.Background(), h.app, "gossip", func(ctx context.Context) error { return nil })
+	Go(context.Background(), child, "catch-up", func(ctx context.Context) error { return nil })
+	waitForGoroutines(t, h, 2)
+
+	if h.app.goroutines.exits == nil || h.app.goroutines.failures == nil {
+		t.Error("counters were not created on first exit")
+	}
+	if n := h.count(t, "ion.goroutine.exits", attribute.String("goroutine", "catch-up")); n != 1 {
+		t.Errorf("catch-up exits = %d, want 1", n)
+	}
+}
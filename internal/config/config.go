@@ -15,6 +15,12 @@ type Config struct {
 	// Default: "info"
 	Level string `yaml:"level" json:"level" env:"LOG_LEVEL"`
 
+	// ComponentLevels overrides the level of named loggers (see Ion.Child / Named).
+	// Comma-separated name=level pairs; a child inherits from its nearest
+	// configured ancestor and "*" sets the global level.
+	// Example: "p2p=debug,consensus.vote=warn,*=info"
+	ComponentLevels string `yaml:"component_levels" json:"component_levels" env:"LOG_COMPONENT_LEVELS"`
+
 	// Development enables development mode with:
 	// - Pretty console output by default
 	// - Caller information in logs
@@ -42,6 +48,20 @@ type Config struct {
 
 	// Metrics configuration for OpenTelemetry metrics.
 	Metrics MetricsConfig `yaml:"metrics" json:"metrics"`
+
+	// Redaction scrubs sensitive fields before they reach an output.
+	Redaction RedactionConfig `yaml:"redaction" json:"redaction"`
+
+	// Sampling rate-limits repeated log entries.
+	Sampling SamplingConfig `yaml:"sampling" json:"sampling"`
+
+	// SetGlobalProviders installs this instance's tracer, meter, and logger
+	// providers and propagator as the OpenTelemetry globals, for libraries
+	// that only use the global API, and routes OpenTelemetry SDK errors to
+	// ion's error handler unless one is already set. Leave it off when several
+	// instances share a process; each instance always uses its own providers.
+	// Default: false
+	SetGlobalProviders bool `yaml:"set_global_providers" json:"set_global_providers" env:"OTEL_SET_GLOBAL_PROVIDERS"`
 }
 
 // ConsoleConfig configures console (stdout/stderr) output.
@@ -137,13 +157,49 @@ type OTELConfig struct {
 	// Default: 5s
 	ExportInterval time.Duration `yaml:"export_interval" json:"export_interval"`
 
-	// Attributes are additional resource attributes for OTEL.
+	// Attributes are additional resource attributes shared by logs, traces, and metrics.
 	// Example: {"environment": "production", "chain": "solana"}
 	Attributes map[string]string `yaml:"attributes" json:"attributes"`
 
 	// Level overrides the global log level for OTEL export.
 	// Optional. If empty, uses global Level.
 	Level string `yaml:"level" json:"level"`
+
+	// Queue stores batches the exporter fails to send on disk and replays
+	// them when the endpoint recovers. Tracing and Metrics inherit it unless
+	// their own Queue is enabled.
+	Queue QueueConfig `yaml:"queue" json:"queue"`
+}
+
+// QueueConfig configures the disk-backed export queue of an OTLP exporter.
+// A batch that fails to send is written to Dir instead of being dropped.
+// While batches are stored, new ones are appended behind them, and a
+// background loop replays them oldest first every RetryInterval until the
+// endpoint accepts them. Stored batches survive restarts.
+//
+// The queue applies to the "grpc" and "http" protocols only.
+type QueueConfig struct {
+	// Enabled controls whether failed exports are queued on disk.
+	// Default: false
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// Dir is the queue directory. Each signal uses its own subdirectory
+	// ("logs", "traces", "metrics"), so signals can share one Dir.
+	// Example: "/var/lib/app/otel-queue"
+	Dir string `yaml:"dir" json:"dir"`
+
+	// MaxSizeMB caps the stored batches of each signal. When a new batch
+	// does not fit, the oldest are dropped.
+	// Default: 100
+	MaxSizeMB int `yaml:"max_size_mb" json:"max_size_mb"`
+
+	// MaxAge drops stored batches older than this instead of replaying them.
+	// Default: 24h
+	MaxAge time.Duration `yaml:"max_age" json:"max_age"`
+
+	// RetryInterval is how often replay is attempted while batches are stored.
+	// Default: 10s
+	RetryInterval time.Duration `yaml:"retry_interval" json:"retry_interval"`
 }
 
 // TracingConfig configures distributed tracing.
@@ -151,9 +207,14 @@ type TracingConfig struct {
 	// Enabled controls whether tracing is active.
 	Enabled bool `yaml:"enabled" json:"enabled"`
 
-	// Protocol: "grpc" or "http".
+	// Protocol: "grpc" or "http", or "stdout" or "file" to write OTLP-JSON
+	// lines locally without a collector.
 	Protocol string `yaml:"protocol" json:"protocol"`
 
+	// File is the rotating output for the "file" protocol. Path and the
+	// rotation settings apply; Enabled and Level are ignored.
+	File FileConfig `yaml:"file" json:"file"`
+
 	// Endpoint is the OTEL collector endpoint for traces.
 	// Falls back to OTEL.Endpoint if not set.
 	Endpoint string `yaml:"endpoint" json:"endpoint"`
@@ -179,14 +240,109 @@ type TracingConfig struct {
 	// ExportInterval for batch export.
 	ExportInterval time.Duration `yaml:"export_interval" json:"export_interval"`
 
-	// Sampler configuration: "always", "never", or "ratio:0.5"
-	Sampler string `yaml:"sampler" json:"sampler"`
-
-	// Propagators: ["tracecontext", "baggage"]
+	// Sampler configuration: "always", "never", or "ratio:0.5".
+	// Prefix with "parentbased_" (e.g. "parentbased_ratio:0.1") to follow the
+	// parent span's sampled flag and apply the sampler only to root spans.
+	Sampler string `yaml:"sampler" json:"sampler" env:"TRACING_SAMPLER"`
+
+	// SamplerRules override Sampler for matching spans; the first matching
+	// rule wins. With a parentbased_ Sampler, spans with a parent follow it
+	// and rules apply only to root spans.
+	// Example: [{Name: "/tx.Submit", Sampler: "always"}, {Name: "/health", Sampler: "never"}]
+	SamplerRules []SamplerRule `yaml:"sampler_rules" json:"sampler_rules"`
+
+	// Propagators selects the context propagation formats used for inject/extract.
+	// Supported: "tracecontext", "baggage", "b3" (single header), "b3multi",
+	// "jaeger", or "none" to disable propagation.
+	// Default (empty): ["tracecontext", "baggage"]
 	Propagators []string `yaml:"propagators" json:"propagators"`
 
-	// Attributes for tracing.
+	// BaggageToLogs lists W3C baggage members copied from the context onto
+	// log entries as fields and onto spans as attributes when they start.
+	// Example: ["tenant", "chain_id"]
+	BaggageToLogs []string `yaml:"baggage_to_logs" json:"baggage_to_logs"`
+
+	// Attributes are additional resource attributes for traces.
+	// Merged over OTEL.Attributes; keys set here win on conflict.
 	Attributes map[string]string `yaml:"attributes" json:"attributes"`
+
+	// TailSampling buffers local traces and keeps the interesting ones.
+	TailSampling TailSamplingConfig `yaml:"tail_sampling" json:"tail_sampling"`
+
+	// LogsAsEvents records log entries as events on the active span.
+	LogsAsEvents LogsAsEventsConfig `yaml:"logs_as_events" json:"logs_as_events"`
+
+	// Queue stores span batches the exporter fails to send on disk.
+	// Inherits OTEL.Queue unless enabled here.
+	Queue QueueConfig `yaml:"queue" json:"queue"`
+}
+
+// SamplerRule selects a sampler for spans by name or start attribute.
+// When both Name and Attribute are set, both must match.
+type SamplerRule struct {
+	// Name matches the span name exactly, or by prefix when it ends in "*"
+	// (e.g. "/tx.*").
+	Name string `yaml:"name" json:"name"`
+
+	// Attribute matches a span start attribute as "key=value".
+	Attribute string `yaml:"attribute" json:"attribute"`
+
+	// Sampler is "always", "never", or "ratio:X".
+	Sampler string `yaml:"sampler" json:"sampler"`
+}
+
+// TailSamplingConfig configures in-process tail-based sampling. Spans of each
+// local trace are buffered until its local root span ends (or Window elapses),
+// then the whole trace is kept if any span has an error status or is slower
+// than LatencyThreshold; other traces are kept at Ratio.
+//
+// The tail sampler only sees traces the head Sampler keeps, and it filters
+// only what reaches the configured exporter. Set Sampler to
+// "parentbased_always" so it decides on every local trace.
+type TailSamplingConfig struct {
+	// Enabled controls whether tail sampling is active.
+	// Default: false
+	Enabled bool `yaml:"enabled" json:"enabled" env:"TRACING_TAIL_SAMPLING_ENABLED"`
+
+	// Window is the longest a trace is buffered before it is decided.
+	// Default: 10s
+	Window time.Duration `yaml:"window" json:"window"`
+
+	// LatencyThreshold keeps traces with any span at least this slow.
+	// Default: 0 (latency does not force a keep)
+	LatencyThreshold time.Duration `yaml:"latency_threshold" json:"latency_threshold"`
+
+	// Ratio is the fraction of remaining traces kept, decided by trace ID.
+	// Default: 0.1
+	Ratio float64 `yaml:"ratio" json:"ratio"`
+
+	// MaxTraces caps buffered traces. When full, the oldest is decided early.
+	// Default: 10000
+	MaxTraces int `yaml:"max_traces" json:"max_traces"`
+
+	// MaxSpansPerTrace caps buffered spans per trace; extra spans are dropped.
+	// Default: 1000
+	MaxSpansPerTrace int `yaml:"max_spans_per_trace" json:"max_spans_per_trace"`
+}
+
+// LogsAsEventsConfig configures the span-to-log bridge. Log entries at or
+// above Level that are written with a context holding a recording span are
+// also added to that span: as an exception event (RecordError) for errors,
+// otherwise as a span event named after the message. Fields become event
+// attributes, after the redaction policy for the "otel" output.
+type LogsAsEventsConfig struct {
+	// Enabled controls whether log entries are recorded as span events.
+	// Default: false
+	Enabled bool `yaml:"enabled" json:"enabled" env:"TRACING_LOGS_AS_EVENTS"`
+
+	// Level is the minimum level recorded: debug, info, warn, error.
+	// Default: "warn"
+	Level string `yaml:"level" json:"level" env:"TRACING_LOGS_AS_EVENTS_LEVEL"`
+
+	// SetErrorStatus marks the span as Error when an Error or Critical entry
+	// is recorded on it.
+	// Default: false
+	SetErrorStatus bool `yaml:"set_error_status" json:"set_error_status"`
 }
 
 // MetricsConfig configures OpenTelemetry metrics export.
@@ -194,9 +350,20 @@ type MetricsConfig struct {
 	// Enabled controls whether metrics export is active.
 	Enabled bool `yaml:"enabled" json:"enabled"`
 
-	// Protocol: "grpc" or "http".
+	// Protocol: "grpc" or "http", or "stdout" or "file" to write OTLP-JSON
+	// lines locally without a collector, or "prometheus" to only serve
+	// Ion.MetricsHandler for scraping.
 	Protocol string `yaml:"protocol" json:"protocol"`
 
+	// Prometheus serves Ion.MetricsHandler for scraping in addition to the
+	// Protocol exporter.
+	// Default: false
+	Prometheus bool `yaml:"prometheus" json:"prometheus" env:"METRICS_PROMETHEUS"`
+
+	// File is the rotating output for the "file" protocol. Path and the
+	// rotation settings apply; Enabled and Level are ignored.
+	File FileConfig `yaml:"file" json:"file"`
+
 	// Endpoint is the OTEL collector endpoint for metrics.
 	// Falls back to OTEL.Endpoint if not set.
 	Endpoint string `yaml:"endpoint" json:"endpoint"`
@@ -221,11 +388,107 @@ type MetricsConfig struct {
 	Interval time.Duration `yaml:"interval" json:"interval"`
 
 	// Temporality preference: "cumulative" (default) or "delta".
-	// Prometheus prefers Cumulative.
+	// With "delta", counters and histograms are exported as deltas while
+	// up-down counters stay cumulative. Prometheus prefers Cumulative.
 	Temporality string `yaml:"temporality" json:"temporality"`
 
-	// Attributes for metrics resource.
+	// Attributes are additional resource attributes for metrics.
+	// Merged over OTEL.Attributes; keys set here win on conflict.
 	Attributes map[string]string `yaml:"attributes" json:"attributes"`
+
+	// Queue stores metric exports that fail to send on disk.
+	// Inherits OTEL.Queue unless enabled here.
+	Queue QueueConfig `yaml:"queue" json:"queue"`
+}
+
+// RedactionConfig configures scrubbing of sensitive log fields.
+// Rules apply to per-call fields and to fields attached via With().
+// Messages are not scanned.
+type RedactionConfig struct {
+	// Enabled controls whether redaction is active.
+	// Default: false
+	Enabled bool `yaml:"enabled" json:"enabled" env:"LOG_REDACTION_ENABLED"`
+
+	// Keys maps field keys (case-insensitive) to an action:
+	// "drop" removes the field, "mask" replaces the value with "[REDACTED]",
+	// "hash" replaces it with a salted HMAC-SHA256 prefix ("sha256:1a2b...")
+	// so values can still be correlated.
+	// Example: {"private_key": "drop", "user_id": "hash", "tx_signature": "mask"}
+	Keys map[string]string `yaml:"keys" json:"keys" env:"LOG_REDACTION_KEYS"`
+
+	// Detectors scan string and error values of every other field and replace
+	// matches with "[REDACTED:<detector>]". Stringers, arrays (e.g.
+	// zap.Strings), objects, and zap.Any values are scanned as rendered and,
+	// on a match, replaced by the scrubbed string. Numbers, bools, times, and
+	// durations are not scanned.
+	// Supported: "private_key" (64 hex chars), "seed_phrase" (12-24 word
+	// mnemonic), "jwt", "email".
+	Detectors []string `yaml:"detectors" json:"detectors" env:"LOG_REDACTION_DETECTORS"`
+
+	// Exempt lists field keys that detectors skip, such as hashes that look
+	// like private keys.
+	// Default: ["tx_hash", "block_hash"]
+	Exempt []string `yaml:"exempt" json:"exempt"`
+
+	// Salt keys the "hash" action and is required when any key uses it.
+	// Use a per-deployment secret so hashes cannot be reversed by
+	// brute-forcing known values.
+	Salt string `yaml:"salt" json:"salt" env:"LOG_REDACTION_SALT"` //nolint:gosec // Required for configuration binding
+
+	// Outputs lists the outputs redaction applies to: "console", "file", "otel".
+	// Default (empty): all outputs. Example: ["file", "otel"] keeps dev console raw.
+	Outputs []string `yaml:"outputs" json:"outputs" env:"LOG_REDACTION_OUTPUTS"`
+}
+
+// SamplingConfig configures log sampling. Within each Interval, the first
+// Initial entries with the same key are written, then every Thereafter-th;
+// the rest are dropped. The key is the message plus the values of KeyFields.
+//
+// The entry written after suppressed ones carries a "sampled" field with the
+// number suppressed. Error and Critical entries are never sampled unless
+// SampleErrors is set.
+type SamplingConfig struct {
+	// Enabled controls whether sampling is active.
+	// Default: false
+	Enabled bool `yaml:"enabled" json:"enabled" env:"LOG_SAMPLING_ENABLED"`
+
+	// Interval is the window the per-key budget applies to.
+	// Default: 1s
+	Interval time.Duration `yaml:"interval" json:"interval" env:"LOG_SAMPLING_INTERVAL"`
+
+	// Initial is how many entries per key are written in each interval.
+	// Default: 100
+	Initial int `yaml:"initial" json:"initial" env:"LOG_SAMPLING_INITIAL"`
+
+	// Thereafter writes every Nth entry after Initial; 0 drops them all.
+	// Default: 100
+	Thereafter int `yaml:"thereafter" json:"thereafter" env:"LOG_SAMPLING_THEREAFTER"`
+
+	// Levels overrides Initial/Thereafter per level ("debug", "info", "warn").
+	// A level with both set to 0 is not sampled.
+	// Example: {"debug": {"initial": 10, "thereafter": 0}}
+	Levels map[string]SamplingRule `yaml:"levels" json:"levels"`
+
+	// KeyFields adds the values of these field keys (per-call or from With)
+	// to the sampling key, e.g. ["peer_id"] to budget each peer separately.
+	// Default (empty): the message alone.
+	KeyFields []string `yaml:"key_fields" json:"key_fields" env:"LOG_SAMPLING_KEY_FIELDS"`
+
+	// SampleErrors also samples Error and Critical entries.
+	// Default: false
+	SampleErrors bool `yaml:"sample_errors" json:"sample_errors"`
+
+	// SummaryInterval, if set, writes an Info entry at most this often with
+	// how many entries each level suppressed since the last summary.
+	// Summaries are written from the logging path, so none appear while idle.
+	// Default: 0 (off)
+	SummaryInterval time.Duration `yaml:"summary_interval" json:"summary_interval" env:"LOG_SAMPLING_SUMMARY_INTERVAL"`
+}
+
+// SamplingRule is the per-interval budget for one level.
+type SamplingRule struct {
+	Initial    int `yaml:"initial" json:"initial"`
+	Thereafter int `yaml:"thereafter" json:"thereafter"`
 }
 
 // Default returns a Config with sensible production defaults.
@@ -256,20 +519,54 @@ func Default() Config {
 			Timeout:        10 * time.Second,
 			BatchSize:      512,
 			ExportInterval: 5 * time.Second,
+			Queue:          defaultQueue(),
 		},
 		Tracing: TracingConfig{
 			Enabled:        false,
 			Sampler:        "ratio:0.1", // 10% sampling for production (safe default)
 			BatchSize:      512,
 			ExportInterval: 5 * time.Second,
+			TailSampling: TailSamplingConfig{
+				Enabled:          false,
+				Window:           10 * time.Second,
+				Ratio:            0.1,
+				MaxTraces:        10000,
+				MaxSpansPerTrace: 1000,
+			},
+			LogsAsEvents: LogsAsEventsConfig{
+				Enabled: false,
+				Level:   "warn",
+			},
+			Queue: defaultQueue(),
 			// Endpoint, Protocol, Auth inherited from OTEL if empty
 		},
 		Metrics: MetricsConfig{
 			Enabled:     false,
 			Interval:    15 * time.Second, // Standard OTel push interval
 			Temporality: "cumulative",     // Prometheus-compatible
+			Queue:       defaultQueue(),
 			// Endpoint, Protocol, Auth inherited from OTEL if empty
 		},
+		Redaction: RedactionConfig{
+			Enabled: false,
+			Exempt:  []string{"tx_hash", "block_hash"},
+		},
+		Sampling: SamplingConfig{
+			Enabled:    false,
+			Interval:   time.Second,
+			Initial:    100,
+			Thereafter: 100,
+		},
+	}
+}
+
+// defaultQueue returns the disk queue defaults shared by all signals.
+func defaultQueue() QueueConfig {
+	return QueueConfig{
+		Enabled:       false,
+		MaxSizeMB:     100,
+		MaxAge:        24 * time.Hour,
+		RetryInterval: 10 * time.Second,
 	}
 }
 
@@ -355,6 +652,34 @@ func NewFileWriter(cfg FileConfig) io.Writer {
 	}
 }
 
+// validRedactionActions, validRedactionDetectors, and validOutputs list the
+// names accepted in RedactionConfig.
+var (
+	validRedactionActions   = map[string]bool{"drop": true, "mask": true, "hash": true}
+	validRedactionDetectors = map[string]bool{"private_key": true, "seed_phrase": true, "jwt": true, "email": true}
+	validOutputs            = map[string]bool{"console": true, "file": true, "otel": true}
+)
+
+// validExportProtocols lists the names accepted in TracingConfig.Protocol and
+// MetricsConfig.Protocol.
+var validExportProtocols = map[string]bool{"grpc": true, "http": true, "stdout": true, "file": true}
+
+// IsLocalProtocol reports whether protocol writes OTLP-JSON lines to stdout or
+// a file instead of sending to a collector.
+func IsLocalProtocol(protocol string) bool {
+	return protocol == "stdout" || protocol == "file"
+}
+
+// validPropagators lists the names accepted in TracingConfig.Propagators.
+var validPropagators = map[string]bool{
+	"tracecontext": true,
+	"baggage":      true,
+	"b3":           true,
+	"b3multi":      true,
+	"jaeger":       true,
+	"none":         true,
+}
+
 // Validate checks the configuration for invalid values.
 // Returns nil if valid, or an error describing all validation failures.
 func (c Config) Validate() error {
@@ -366,6 +691,18 @@ func (c Config) Validate() error {
 		errs = append(errs, fmt.Sprintf("invalid level %q (use: debug, info, warn, error, fatal)", c.Level))
 	}
 
+	// Validate component levels
+	for _, part := range strings.Split(c.ComponentLevels, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, level, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(name) == "" || !validLevels[strings.ToLower(strings.TrimSpace(level))] {
+			errs = append(errs, fmt.Sprintf("invalid component level %q (use: name=level, e.g. p2p=debug)", part))
+		}
+	}
+
 	// Validate console format
 	if c.Console.Format != "" && c.Console.Format != "json" && c.Console.Format != "pretty" && c.Console.Format != "systemd" {
 		errs = append(errs, fmt.Sprintf("invalid console format %q (use: json, pretty, systemd)", c.Console.Format))
@@ -395,26 +732,105 @@ func (c Config) Validate() error {
 		errs = append(errs, fmt.Sprintf("invalid OTEL protocol %q (use: grpc, http)", c.OTEL.Protocol))
 	}
 
+	errs = append(errs, validateQueue("OTEL", c.OTEL.Queue)...)
+
 	// Validate tracing config
-	if c.Tracing.Enabled && c.Tracing.Endpoint == "" && c.OTEL.Endpoint == "" {
+	if c.Tracing.Enabled && c.Tracing.Endpoint == "" && c.OTEL.Endpoint == "" && !IsLocalProtocol(c.Tracing.Protocol) {
 		errs = append(errs, "tracing enabled but no endpoint (set Tracing.Endpoint or OTEL.Endpoint)")
 	}
-	if c.Tracing.Protocol != "" && c.Tracing.Protocol != "grpc" && c.Tracing.Protocol != "http" {
-		errs = append(errs, fmt.Sprintf("invalid tracing protocol %q (use: grpc, http)", c.Tracing.Protocol))
+	if c.Tracing.Protocol != "" && !validExportProtocols[c.Tracing.Protocol] {
+		errs = append(errs, fmt.Sprintf("invalid tracing protocol %q (use: grpc, http, stdout, file)", c.Tracing.Protocol))
+	}
+	if c.Tracing.Enabled && c.Tracing.Protocol == "file" && c.Tracing.File.Path == "" {
+		errs = append(errs, "tracing protocol is file but Tracing.File.Path is empty")
+	}
+	for _, p := range c.Tracing.Propagators {
+		if !validPropagators[strings.ToLower(p)] {
+			errs = append(errs, fmt.Sprintf("invalid tracing propagator %q (use: tracecontext, baggage, b3, b3multi, jaeger, none)", p))
+		} else if strings.EqualFold(p, "none") && len(c.Tracing.Propagators) > 1 {
+			errs = append(errs, "tracing propagator \"none\" cannot be combined with other propagators")
+		}
+	}
+
+	for _, k := range c.Tracing.BaggageToLogs {
+		if strings.TrimSpace(k) == "" {
+			errs = append(errs, "tracing baggage_to_logs contains an empty key")
+			break
+		}
+	}
+
+	if _, err := ParseSampler(c.Tracing.Sampler); err != nil {
+		errs = append(errs, err.Error())
+	}
+	for i, r := range c.Tracing.SamplerRules {
+		if err := validateSamplerRule(i, r); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if ts := c.Tracing.TailSampling; ts.Enabled {
+		if ts.Ratio < 0 || ts.Ratio > 1 {
+			errs = append(errs, fmt.Sprintf("invalid tail sampling ratio %v (use: 0 to 1)", ts.Ratio))
+		}
+		if ts.Window < 0 || ts.LatencyThreshold < 0 || ts.MaxTraces < 0 || ts.MaxSpansPerTrace < 0 {
+			errs = append(errs, "tail sampling window, latency_threshold, max_traces, and max_spans_per_trace cannot be negative")
+		}
+	}
+	errs = append(errs, validateQueue("tracing", c.Tracing.Queue)...)
+	if le := c.Tracing.LogsAsEvents; le.Level != "" && !validLevels[strings.ToLower(le.Level)] {
+		errs = append(errs, fmt.Sprintf("invalid logs_as_events level %q (use: debug, info, warn, error, fatal)", le.Level))
 	}
 
 	// Validate metrics config
 	if c.Metrics.Enabled {
-		if c.Metrics.Endpoint == "" && c.OTEL.Endpoint == "" {
+		if c.Metrics.Endpoint == "" && c.OTEL.Endpoint == "" && !IsLocalProtocol(c.Metrics.Protocol) && c.Metrics.Protocol != "prometheus" {
 			errs = append(errs, "metrics enabled but no endpoint (set Metrics.Endpoint or OTEL.Endpoint)")
 		}
-		if c.Metrics.Protocol != "" && c.Metrics.Protocol != "grpc" && c.Metrics.Protocol != "http" {
-			errs = append(errs, fmt.Sprintf("invalid metrics protocol %q (use: grpc, http)", c.Metrics.Protocol))
+		if c.Metrics.Protocol != "" && !validExportProtocols[c.Metrics.Protocol] && c.Metrics.Protocol != "prometheus" {
+			errs = append(errs, fmt.Sprintf("invalid metrics protocol %q (use: grpc, http, stdout, file, prometheus)", c.Metrics.Protocol))
+		}
+		if c.Metrics.Protocol == "file" && c.Metrics.File.Path == "" {
+			errs = append(errs, "metrics protocol is file but Metrics.File.Path is empty")
 		}
 		if c.Metrics.Temporality != "" && c.Metrics.Temporality != "cumulative" && c.Metrics.Temporality != "delta" {
 			errs = append(errs, fmt.Sprintf("invalid metrics temporality %q (use: cumulative, delta)", c.Metrics.Temporality))
 		}
 	}
+	errs = append(errs, validateQueue("metrics", c.Metrics.Queue)...)
+
+	// Validate redaction config
+	for k, action := range c.Redaction.Keys {
+		if !validRedactionActions[strings.ToLower(action)] {
+			errs = append(errs, fmt.Sprintf("invalid redaction action %q for key %q (use: drop, mask, hash)", action, k))
+		} else if strings.EqualFold(action, "hash") && c.Redaction.Salt == "" {
+			errs = append(errs, fmt.Sprintf("redaction key %q uses hash but no salt is set", k))
+		}
+	}
+	for _, d := range c.Redaction.Detectors {
+		if !validRedactionDetectors[strings.ToLower(d)] {
+			errs = append(errs, fmt.Sprintf("invalid redaction detector %q (use: private_key, seed_phrase, jwt, email)", d))
+		}
+	}
+	for _, o := range c.Redaction.Outputs {
+		if !validOutputs[strings.ToLower(o)] {
+			errs = append(errs, fmt.Sprintf("invalid redaction output %q (use: console, file, otel)", o))
+		}
+	}
+
+	// Validate sampling config
+	if c.Sampling.Interval < 0 || c.Sampling.SummaryInterval < 0 {
+		errs = append(errs, "sampling intervals cannot be negative")
+	}
+	if c.Sampling.Initial < 0 || c.Sampling.Thereafter < 0 {
+		errs = append(errs, "sampling initial and thereafter cannot be negative")
+	}
+	for lvl, r := range c.Sampling.Levels {
+		if !validLevels[strings.ToLower(lvl)] {
+			errs = append(errs, fmt.Sprintf("invalid sampling level %q", lvl))
+		}
+		if r.Initial < 0 || r.Thereafter < 0 {
+			errs = append(errs, fmt.Sprintf("sampling level %q: initial and thereafter cannot be negative", lvl))
+		}
+	}
 
 	if len(errs) > 0 {
 		return fmt.Errorf("config validation failed: %s", strings.Join(errs, "; "))
@@ -436,3 +852,18 @@ func (c Config) Validate() error {
 	}
 	return nil
 }
+
+// validateQueue checks an enabled QueueConfig; name prefixes the messages.
+func validateQueue(name string, q QueueConfig) []string {
+	if !q.Enabled {
+		return nil
+	}
+	var errs []string
+	if q.Dir == "" {
+		errs = append(errs, name+" queue enabled but dir is empty")
+	}
+	if q.MaxSizeMB < 0 || q.MaxAge < 0 || q.RetryInterval < 0 {
+		errs = append(errs, name+" queue max_size_mb, max_age, and retry_interval cannot be negative")
+	}
+	return errs
+}
@@ -0,0 +1,223 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate_Propagators(t *testing.T) {
+	tests := []struct {
+		name        string
+		propagators []string
+		wantErr     string
+	}{
+		{"empty", nil, ""},
+		{"all known", []string{"tracecontext", "baggage", "b3", "b3multi", "jaeger"}, ""},
+		{"case insensitive", []string{"B3"}, ""},
+		{"none alone", []string{"none"}, ""},
+		{"unknown", []string{"tracecontext", "xray"}, `invalid tracing propagator "xray"`},
+		{"none combined", []string{"none", "b3"}, `"none" cannot be combined`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Tracing.Propagators = tt.propagators
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_ComponentLevels(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"", false},
+		{"p2p=debug", false},
+		{"p2p=debug, consensus.vote=WARN ,*=info", false},
+		{"p2p", true},
+		{"=debug", true},
+		{"p2p=loud", true},
+	}
+
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.ComponentLevels = tt.spec
+		err := cfg.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate_Redaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RedactionConfig
+		wantErr string
+	}{
+		{"empty", RedactionConfig{}, ""},
+		{"valid", RedactionConfig{Keys: map[string]string{"user_id": "HASH"}, Salt: "s1", Detectors: []string{"jwt"}, Outputs: []string{"otel"}}, ""},
+		{"hash without salt", RedactionConfig{Enabled: true, Keys: map[string]string{"user_id": "hash"}}, `redaction key "user_id" uses hash but no salt`},
+		{"mask without salt", RedactionConfig{Enabled: true, Keys: map[string]string{"user_id": "mask"}}, ""},
+		{"bad action", RedactionConfig{Keys: map[string]string{"user_id": "shred"}}, `invalid redaction action "shred"`},
+		{"bad detector", RedactionConfig{Detectors: []string{"ssn"}}, `invalid redaction detector "ssn"`},
+		{"bad output", RedactionConfig{Outputs: []string{"syslog"}}, `invalid redaction output "syslog"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Redaction = tt.cfg
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Sampling(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*SamplingConfig)
+		wantErr bool
+	}{
+		{"defaults", func(*SamplingConfig) {}, false},
+		{"level override", func(s *SamplingConfig) { s.Levels = map[string]SamplingRule{"debug": {Initial: 10}} }, false},
+		{"negative initial", func(s *SamplingConfig) { s.Initial = -1 }, true},
+		{"negative interval", func(s *SamplingConfig) { s.Interval = -time.Second }, true},
+		{"unknown level", func(s *SamplingConfig) { s.Levels = map[string]SamplingRule{"trace": {Initial: 1}} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.mutate(&cfg.Sampling)
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_TailSampling(t *testing.T) {
+	cfg := Default()
+	cfg.Tracing.TailSampling.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with defaults error: %v", err)
+	}
+
+	cfg.Tracing.TailSampling.Ratio = 1.5
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "tail sampling ratio") {
+		t.Errorf("Validate() error = %v, want tail sampling ratio error", err)
+	}
+}
+
+func TestValidate_LogsAsEvents(t *testing.T) {
+	cfg := Default()
+	cfg.Tracing.LogsAsEvents.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with defaults error: %v", err)
+	}
+
+	cfg.Tracing.LogsAsEvents.Level = "loud"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "logs_as_events level") {
+		t.Errorf("Validate() error = %v, want logs_as_events level error", err)
+	}
+}
+
+func TestValidate_BaggageToLogs(t *testing.T) {
+	cfg := Default()
+	cfg.Tracing.BaggageToLogs = []string{"tenant", " "}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "baggage_to_logs") {
+		t.Errorf("Validate() error = %v, want baggage_to_logs error", err)
+	}
+}
+
+func TestValidate_LocalProtocols(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr bool
+	}{
+		{"tracing stdout without endpoint", func(c *Config) {
+			c.Tracing.Enabled, c.Tracing.Protocol = true, "stdout"
+		}, false},
+		{"tracing file", func(c *Config) {
+			c.Tracing.Enabled, c.Tracing.Protocol, c.Tracing.File.Path = true, "file", "/var/lib/node/traces.jsonl"
+		}, false},
+		{"tracing file without path", func(c *Config) {
+			c.Tracing.Enabled, c.Tracing.Protocol = true, "file"
+		}, true},
+		{"metrics file", func(c *Config) {
+			c.Metrics.Enabled, c.Metrics.Protocol, c.Metrics.File.Path = true, "file", "/var/lib/node/metrics.jsonl"
+		}, false},
+		{"metrics file without path", func(c *Config) {
+			c.Metrics.Enabled, c.Metrics.Protocol = true, "file"
+		}, true},
+		{"metrics prometheus without endpoint", func(c *Config) {
+			c.Metrics.Enabled, c.Metrics.Protocol = true, "prometheus"
+		}, false},
+		{"tracing prometheus", func(c *Config) { c.Tracing.Protocol = "prometheus" }, true},
+		{"unknown protocol", func(c *Config) { c.Tracing.Protocol = "kafka" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Queue(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr bool
+	}{
+		{"disabled without dir", func(c *Config) {}, false},
+		{"otel queue", func(c *Config) {
+			c.OTEL.Queue.Enabled, c.OTEL.Queue.Dir = true, "/var/lib/node/otel-queue"
+		}, false},
+		{"otel queue without dir", func(c *Config) { c.OTEL.Queue.Enabled = true }, true},
+		{"tracing queue without dir", func(c *Config) { c.Tracing.Queue.Enabled = true }, true},
+		{"metrics negative max age", func(c *Config) {
+			c.Metrics.Queue = QueueConfig{Enabled: true, Dir: "/tmp/q", MaxAge: -time.Hour}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
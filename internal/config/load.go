@@ -0,0 +1,343 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load builds a Config from Default(), merges the YAML or JSON file at path
+// on top of it, applies environment overrides, and validates the result.
+//
+// The format is chosen by extension: ".json" is decoded as JSON, anything
+// else as YAML. Keys follow the `yaml`/`json` struct tags and durations are
+// written as Go duration strings (e.g. "5s", "250ms").
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is supplied by the operator
+	if err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	tag := "yaml"
+	var raw map[string]any
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		tag = "json"
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err = dec.Decode(&raw)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	if err := assign(reflect.ValueOf(&cfg).Elem(), raw, "", tag); err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	if err := ApplyEnv(&cfg); err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// FromEnv builds a Config from Default(), applies environment overrides,
+// and validates the result.
+func FromEnv() (Config, error) {
+	cfg := Default()
+	if err := ApplyEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// ApplyEnv overrides every field carrying an `env` struct tag with the value
+// of that environment variable, if set. Unset variables leave the field untouched.
+func ApplyEnv(cfg *Config) error {
+	return applyEnv(reflect.ValueOf(cfg).Elem())
+}
+
+func applyEnv(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+
+		if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+			if err := applyEnv(fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := sf.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		s, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setFromString(fv, s); err != nil {
+			return fmt.Errorf("env %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// assign copies a decoded YAML/JSON value into v, matching struct fields by
+// the given tag. key is the dotted path used in error messages.
+func assign(v reflect.Value, raw any, key, tag string) error {
+	if raw == nil {
+		return nil
+	}
+
+	if v.Type() == durationType {
+		switch r := raw.(type) {
+		case string:
+			d, err := time.ParseDuration(r)
+			if err != nil {
+				return fmt.Errorf("key %q: invalid duration %q", key, r)
+			}
+			v.SetInt(int64(d))
+			return nil
+		default:
+			if n, ok := toInt64(raw); ok && n == 0 {
+				v.SetInt(0)
+				return nil
+			}
+			return fmt.Errorf("key %q: expected duration string (e.g. \"5s\"), got %v", key, raw)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("key %q: expected mapping, got %T", key, raw)
+		}
+		fields := fieldsByTag(v.Type(), tag)
+		for k, val := range m {
+			idx, ok := fields[k]
+			if !ok {
+				return fmt.Errorf("key %q: unknown key", joinKey(key, k))
+			}
+			if err := assign(v.Field(idx), val, joinKey(key, k), tag); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("key %q: expected string, got %v", key, raw)
+		}
+		v.SetString(s)
+		return nil
+
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return fmt.Errorf("key %q: expected bool, got %v", key, raw)
+		}
+		v.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := toInt64(raw)
+		if !ok {
+			return fmt.Errorf("key %q: expected integer, got %v", key, raw)
+		}
+		if v.OverflowInt(n) {
+			return fmt.Errorf("key %q: %d is out of range for %s", key, n, v.Type())
+		}
+		v.SetInt(n)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat64(raw)
+		if !ok {
+			return fmt.Errorf("key %q: expected number, got %v", key, raw)
+		}
+		if v.OverflowFloat(f) {
+			return fmt.Errorf("key %q: %v is out of range for %s", key, f, v.Type())
+		}
+		v.SetFloat(f)
+		return nil
+
+	case reflect.Slice:
+		items, ok := raw.([]any)
+		if !ok {
+			return fmt.Errorf("key %q: expected list, got %T", key, raw)
+		}
+		out := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := assign(out.Index(i), item, fmt.Sprintf("%s[%d]", key, i), tag); err != nil {
+				return err
+			}
+		}
+		v.Set(out)
+		return nil
+
+	case reflect.Map:
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("key %q: expected mapping, got %T", key, raw)
+		}
+		out := reflect.MakeMapWithSize(v.Type(), len(m))
+		for k, val := range m {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := assign(elem, val, joinKey(key, k), tag); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(k), elem)
+		}
+		v.Set(out)
+		return nil
+	}
+
+	return fmt.Errorf("key %q: unsupported field type %s", key, v.Type())
+}
+
+// setFromString parses an environment variable value into v.
+// Lists are comma-separated; maps are comma-separated key=value pairs.
+func setFromString(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", s)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", s)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q for %s", s, v.Type())
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q for %s", s, v.Type())
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported field type %s", v.Type())
+		}
+		parts := splitList(s)
+		out := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			out.Index(i).SetString(p)
+		}
+		v.Set(out)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported field type %s", v.Type())
+		}
+		out := reflect.MakeMap(v.Type())
+		for _, p := range splitList(s) {
+			k, val, ok := strings.Cut(p, "=")
+			if !ok {
+				return fmt.Errorf("invalid key=value pair %q", p)
+			}
+			out.SetMapIndex(reflect.ValueOf(strings.TrimSpace(k)), reflect.ValueOf(strings.TrimSpace(val)))
+		}
+		v.Set(out)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// fieldsByTag maps tag names to field indexes for a struct type.
+func fieldsByTag(t reflect.Type, tag string) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get(tag), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = i
+	}
+	return fields
+}
+
+func joinKey(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func toInt64(raw any) (int64, bool) {
+	switch n := raw.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint64:
+		if n > uint64(1<<63-1) {
+			return 0, false
+		}
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func toFloat64(raw any) (float64, bool) {
+	switch n := raw.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	if i, ok := toInt64(raw); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestLoad_YAML(t *testing.T) {
+	path := writeFile(t, "ion.yaml", `
+service_name: payment-node
+level: debug
+otel:
+  enabled: true
+  endpoint: collector:4317
+  timeout: 3s
+  headers:
+    x-tenant: jm
+tracing:
+  enabled: true
+  propagators: [tracecontext, baggage]
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.ServiceName != "payment-node" {
+		t.Errorf("ServiceName = %q, want %q", cfg.ServiceName, "payment-node")
+	}
+	if cfg.Level != "debug" {
+		t.Errorf("Level = %q, want %q", cfg.Level, "debug")
+	}
+	if cfg.OTEL.Timeout != 3*time.Second {
+		t.Errorf("OTEL.Timeout = %v, want 3s", cfg.OTEL.Timeout)
+	}
+	if cfg.OTEL.Headers["x-tenant"] != "jm" {
+		t.Errorf("OTEL.Headers = %v, want x-tenant=jm", cfg.OTEL.Headers)
+	}
+	if len(cfg.Tracing.Propagators) != 2 {
+		t.Errorf("Tracing.Propagators = %v, want 2 entries", cfg.Tracing.Propagators)
+	}
+	// Untouched values keep their defaults.
+	if cfg.OTEL.BatchSize != 512 {
+		t.Errorf("OTEL.BatchSize = %d, want default 512", cfg.OTEL.BatchSize)
+	}
+	if cfg.Metrics.Interval != 15*time.Second {
+		t.Errorf("Metrics.Interval = %v, want default 15s", cfg.Metrics.Interval)
+	}
+}
+
+func TestLoad_JSON(t *testing.T) {
+	path := writeFile(t, "ion.json", `{
+		"service_name": "bridge",
+		"file": {"enabled": true, "path": "/tmp/bridge.log", "max_size_mb": 50},
+		"metrics": {"enabled": true, "endpoint": "collector:4317", "interval": "30s"}
+	}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.File.MaxSizeMB != 50 {
+		t.Errorf("File.MaxSizeMB = %d, want 50", cfg.File.MaxSizeMB)
+	}
+	if cfg.Metrics.Interval != 30*time.Second {
+		t.Errorf("Metrics.Interval = %v, want 30s", cfg.Metrics.Interval)
+	}
+}
+
+func TestLoad_EnvOverridesFile(t *testing.T) {
+	path := writeFile(t, "ion.yaml", "service_name: from-file\nlevel: info\n")
+	t.Setenv("SERVICE_NAME", "from-env")
+	t.Setenv("LOG_DEVELOPMENT", "true")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.ServiceName != "from-env" {
+		t.Errorf("ServiceName = %q, want %q", cfg.ServiceName, "from-env")
+	}
+	if !cfg.Development {
+		t.Error("Development = false, want true from LOG_DEVELOPMENT")
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"bad duration", "a.yaml", "otel:\n  timeout: 5x\n", `"otel.timeout": invalid duration "5x"`},
+		{"unknown key", "b.yaml", "tracing:\n  sampel: always\n", `"tracing.sampel": unknown key`},
+		{"wrong type", "c.json", `{"file": {"max_size_mb": "big"}}`, `"file.max_size_mb": expected integer`},
+		{"validation", "d.yaml", "level: loud\n", `invalid level "loud"`},
+		{"integer too large", "e.json", `{"sampling": {"initial": 9223372036854775808}}`, `"sampling.initial": expected integer`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, tt.file, tt.content)
+			_, err := Load(path)
+			if err == nil {
+				t.Fatal("Load() error = nil, want error")
+			}
+			if !strings.Contains(err.Error(), path) {
+				t.Errorf("error %q does not name the file", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssign_OutOfRange(t *testing.T) {
+	var dst struct {
+		Small int8
+		Ratio float32
+	}
+	v := reflect.ValueOf(&dst).Elem()
+
+	if err := assign(v.Field(0), 300, "a.small", "yaml"); err == nil || !strings.Contains(err.Error(), `key "a.small": 300 is out of range for int8`) {
+		t.Errorf("assign(int8, 300) error = %v, want out of range", err)
+	}
+	if err := assign(v.Field(1), 1e39, "a.ratio", "yaml"); err == nil || !strings.Contains(err.Error(), `key "a.ratio"`) {
+		t.Errorf("assign(float32, 1e39) error = %v, want out of range", err)
+	}
+	if err := setFromString(v.Field(0), "300"); err == nil {
+		t.Error("setFromString(int8, 300) error = nil, want out of range")
+	}
+	if err := assign(v.Field(0), -128, "a.small", "yaml"); err != nil || dst.Small != -128 {
+		t.Errorf("assign(int8, -128) = %v, Small = %d; want it stored", err, dst.Small)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("OTEL_USERNAME", "svc")
+
+	cfg, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error: %v", err)
+	}
+	if cfg.Level != "warn" {
+		t.Errorf("Level = %q, want %q", cfg.Level, "warn")
+	}
+	if cfg.OTEL.Username != "svc" {
+		t.Errorf("OTEL.Username = %q, want %q", cfg.OTEL.Username, "svc")
+	}
+
+	t.Setenv("LOG_DEVELOPMENT", "maybe")
+	if _, err := FromEnv(); err == nil || !strings.Contains(err.Error(), "LOG_DEVELOPMENT") {
+		t.Errorf("FromEnv() error = %v, want error naming LOG_DEVELOPMENT", err)
+	}
+}
+
+// Each env variable must bind exactly one field; a tag on a struct embedded
+// in several places would override all of them at once.
+func TestApplyEnv_TagsAreUnique(t *testing.T) {
+	seen := map[string]string{}
+	var walk func(typ reflect.Type, path string)
+	walk = func(typ reflect.Type, path string) {
+		for i := 0; i < typ.NumField(); i++ {
+			sf := typ.Field(i)
+			if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+				walk(sf.Type, path+sf.Name+".")
+				continue
+			}
+			name := sf.Tag.Get("env")
+			if name == "" {
+				continue
+			}
+			if prev, ok := seen[name]; ok {
+				t.Errorf("env %s binds both %s and %s", name, prev, path+sf.Name)
+			}
+			seen[name] = path + sf.Name
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+}
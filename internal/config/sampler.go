@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SamplerSpec is a parsed TracingConfig.Sampler or SamplerRule.Sampler value.
+type SamplerSpec struct {
+	// ParentBased follows the parent span's sampled flag when there is a
+	// parent, and applies Kind only to root spans.
+	ParentBased bool
+
+	// Kind is "always", "never", or "ratio".
+	Kind string
+
+	// Ratio is the sampled fraction for Kind "ratio", in [0, 1].
+	Ratio float64
+}
+
+// ParseSampler parses a sampler string:
+//
+//	always | never | ratio:<0..1>
+//	parentbased_always | parentbased_never | parentbased_ratio:<0..1>
+//
+// An empty string means "always".
+func ParseSampler(s string) (SamplerSpec, error) {
+	var spec SamplerSpec
+	rest := strings.ToLower(strings.TrimSpace(s))
+	if after, ok := strings.CutPrefix(rest, "parentbased_"); ok {
+		spec.ParentBased = true
+		rest = after
+	}
+
+	switch {
+	case rest == "" && !spec.ParentBased, rest == "always":
+		spec.Kind = "always"
+	case rest == "never":
+		spec.Kind = "never"
+	case strings.HasPrefix(rest, "ratio:"):
+		ratio, err := strconv.ParseFloat(strings.TrimPrefix(rest, "ratio:"), 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return SamplerSpec{}, fmt.Errorf("invalid sampler %q (ratio must be a number from 0 to 1)", s)
+		}
+		spec.Kind = "ratio"
+		spec.Ratio = ratio
+	default:
+		return SamplerSpec{}, fmt.Errorf("invalid sampler %q (use: always, never, ratio:X, or parentbased_ prefixed)", s)
+	}
+	return spec, nil
+}
+
+// validateSamplerRule checks a rule's matcher and sampler.
+func validateSamplerRule(i int, r SamplerRule) error {
+	if r.Name == "" && r.Attribute == "" {
+		return fmt.Errorf("sampler rule %d: set name or attribute", i)
+	}
+	if r.Attribute != "" {
+		if k, _, ok := strings.Cut(r.Attribute, "="); !ok || strings.TrimSpace(k) == "" {
+			return fmt.Errorf("sampler rule %d: invalid attribute %q (use: key=value)", i, r.Attribute)
+		}
+	}
+	spec, err := ParseSampler(r.Sampler)
+	if err != nil {
+		return fmt.Errorf("sampler rule %d: %w", i, err)
+	}
+	if spec.ParentBased {
+		return fmt.Errorf("sampler rule %d: parentbased samplers are not allowed in rules (set Tracing.Sampler instead)", i)
+	}
+	return nil
+}
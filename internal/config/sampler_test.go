@@ -0,0 +1,64 @@
+package config
+
+import "testing"
+
+func TestParseSampler(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    SamplerSpec
+		wantErr bool
+	}{
+		{"", SamplerSpec{Kind: "always"}, false},
+		{"always", SamplerSpec{Kind: "always"}, false},
+		{"never", SamplerSpec{Kind: "never"}, false},
+		{"ratio:0.25", SamplerSpec{Kind: "ratio", Ratio: 0.25}, false},
+		{"parentbased_always", SamplerSpec{ParentBased: true, Kind: "always"}, false},
+		{"ParentBased_Ratio:0.1", SamplerSpec{ParentBased: true, Kind: "ratio", Ratio: 0.1}, false},
+		{"parentbased_", SamplerSpec{}, true},
+		{"ratio:abc", SamplerSpec{}, true},
+		{"ratio:1.5", SamplerSpec{}, true},
+		{"sometimes", SamplerSpec{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSampler(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSampler(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSampler(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_Sampler(t *testing.T) {
+	tests := []struct {
+		name    string
+		sampler string
+		rules   []SamplerRule
+		wantErr bool
+	}{
+		{"default", "ratio:0.1", nil, false},
+		{"invalid sampler", "ratio:x", nil, true},
+		{"valid rules", "parentbased_ratio:0.1", []SamplerRule{
+			{Name: "/tx.Submit", Sampler: "always"},
+			{Attribute: "url.path=/health", Sampler: "never"},
+		}, false},
+		{"rule without matcher", "always", []SamplerRule{{Sampler: "never"}}, true},
+		{"rule bad attribute", "always", []SamplerRule{{Attribute: "novalue", Sampler: "never"}}, true},
+		{"rule parentbased", "always", []SamplerRule{{Name: "x", Sampler: "parentbased_always"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Tracing.Sampler = tt.sampler
+			cfg.Tracing.SamplerRules = tt.rules
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
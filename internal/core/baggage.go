@@ -0,0 +1,37 @@
+package core
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// baggageProcessor copies allow-listed baggage members from the parent
+// context onto each span as it starts (config.TracingConfig.BaggageToLogs).
+type baggageProcessor struct {
+	keys []string
+}
+
+// NewBaggageProcessor returns a span processor that sets the baggage members
+// named by keys as span attributes of the same name.
+func NewBaggageProcessor(keys []string) sdktrace.SpanProcessor {
+	return &baggageProcessor{keys: keys}
+}
+
+func (p *baggageProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	bag := baggage.FromContext(ctx)
+	if bag.Len() == 0 {
+		return
+	}
+	for _, k := range p.keys {
+		if m := bag.Member(k); m.Key() != "" {
+			s.SetAttributes(attribute.String(k, m.Value()))
+		}
+	}
+}
+
+func (p *baggageProcessor) OnEnd(sdktrace.ReadOnlySpan)      {}
+func (p *baggageProcessor) Shutdown(context.Context) error   { return nil }
+func (p *baggageProcessor) ForceFlush(context.Context) error { return nil }
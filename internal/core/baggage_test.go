@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestBaggageProcessor(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewBaggageProcessor([]string{"tenant", "chain_id"})),
+		sdktrace.WithSpanProcessor(rec),
+	)
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+
+	tenant, _ := baggage.NewMemberRaw("tenant", "acme")
+	secret, _ := baggage.NewMemberRaw("session", "s3cr3t")
+	bag, _ := baggage.New(tenant, secret)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	ctx, parent := tp.Tracer("test").Start(ctx, "parent")
+	_, child := tp.Tracer("test").Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	for _, s := range rec.Ended() {
+		if got, ok := eventAttr(s.Attributes(), "tenant"); !ok || got != "acme" {
+			t.Errorf("%s: tenant = %q (present %v), want acme", s.Name(), got, ok)
+		}
+		if _, ok := eventAttr(s.Attributes(), "session"); ok {
+			t.Errorf("%s: copied session, which is not allow-listed", s.Name())
+		}
+		if _, ok := eventAttr(s.Attributes(), "chain_id"); ok {
+			t.Errorf("%s: set chain_id, which is not in the baggage", s.Name())
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// CustomOutput is the output name of zap cores supplied through Components.
+// They share one per-output level, set with SetOutputLevel("custom", ...).
+const CustomOutput = "custom"
+
+// Components are zap and OpenTelemetry components supplied by the caller
+// (ion.New options) in addition to those built from Config. ion owns their
+// lifecycle: they are shut down with the provider they are attached to.
+type Components struct {
+	// ZapCores are extra log outputs, gated and redacted like the built-in ones.
+	ZapCores []zapcore.Core
+
+	// SpanProcessors are added to the tracer provider. A provider is created
+	// for them (and for SpanExporters) even when Tracing.Enabled is false.
+	SpanProcessors []sdktrace.SpanProcessor
+
+	// SpanExporters are added to the tracer provider, each behind its own
+	// batch span processor configured like the OTLP one.
+	SpanExporters []sdktrace.SpanExporter
+
+	// LogExporters receive the "otel" log output, each behind its own batch
+	// processor. The output is enabled for them even when OTEL.Enabled is false.
+	LogExporters []sdklog.Exporter
+
+	// MetricReaders are added to the meter provider. A provider is created
+	// for them even when Metrics.Enabled is false.
+	MetricReaders []sdkmetric.Reader
+
+	// Resource is merged over the resource built from Config for every
+	// signal; its attributes win on conflict. Nil adds nothing.
+	Resource *resource.Resource
+}
@@ -8,3 +8,7 @@ const SentinelKey = "__ion_ctx__"
 // SystemFieldPrefix is the reserved prefix for internal system fields.
 // Users should avoid keys starting with this prefix.
 const SystemFieldPrefix = "__ion_"
+
+// InstrumentationName is the instrumentation scope of ion's own telemetry,
+// such as the export queue metrics.
+const InstrumentationName = "github.com/JupiterMetaLabs/ion"
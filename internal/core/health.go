@@ -0,0 +1,109 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ExportHealth records the outcome of export calls for one signal.
+// It is safe for concurrent use; a nil *ExportHealth reports nothing.
+type ExportHealth struct {
+	successes atomic.Uint64
+	failures  atomic.Uint64
+
+	mu          sync.Mutex
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   string
+}
+
+// HealthSnapshot is a point-in-time copy of ExportHealth.
+type HealthSnapshot struct {
+	Successes   uint64    `json:"successes"`
+	Failures    uint64    `json:"failures"`
+	LastSuccess time.Time `json:"last_success,omitzero"`
+	LastFailure time.Time `json:"last_failure,omitzero"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// Healthy reports whether the most recent export succeeded (or none ran yet).
+func (s HealthSnapshot) Healthy() bool {
+	return s.LastFailure.IsZero() || s.LastSuccess.After(s.LastFailure)
+}
+
+// Record stores the outcome of one export call.
+func (h *ExportHealth) Record(err error) {
+	if h == nil {
+		return
+	}
+	now := time.Now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err != nil {
+		h.failures.Add(1)
+		h.lastFailure = now
+		h.lastError = err.Error()
+		return
+	}
+	h.successes.Add(1)
+	h.lastSuccess = now
+}
+
+// Snapshot returns the current counters and timestamps.
+func (h *ExportHealth) Snapshot() HealthSnapshot {
+	if h == nil {
+		return HealthSnapshot{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return HealthSnapshot{
+		Successes:   h.successes.Load(),
+		Failures:    h.failures.Load(),
+		LastSuccess: h.lastSuccess,
+		LastFailure: h.lastFailure,
+		LastError:   h.lastError,
+	}
+}
+
+// healthSpanExporter records the outcome of every span export.
+type healthSpanExporter struct {
+	sdktrace.SpanExporter
+	health *ExportHealth
+}
+
+func (e *healthSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.health.Record(err)
+	return err
+}
+
+// healthLogExporter records the outcome of every log export.
+type healthLogExporter struct {
+	sdklog.Exporter
+	health *ExportHealth
+}
+
+func (e *healthLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	err := e.Exporter.Export(ctx, records)
+	e.health.Record(err)
+	return err
+}
+
+// healthMetricExporter records the outcome of every metric export.
+type healthMetricExporter struct {
+	sdkmetric.Exporter
+	health *ExportHealth
+}
+
+func (e *healthMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	err := e.Exporter.Export(ctx, rm)
+	e.health.Record(err)
+	return err
+}
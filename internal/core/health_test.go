@@ -0,0 +1,37 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExportHealth(t *testing.T) {
+	var nilHealth *ExportHealth
+	nilHealth.Record(errors.New("ignored"))
+	if got := nilHealth.Snapshot(); got.Successes != 0 || got.Failures != 0 {
+		t.Errorf("nil Snapshot() = %+v, want zero", got)
+	}
+
+	h := &ExportHealth{}
+	if !h.Snapshot().Healthy() {
+		t.Error("Healthy() before any export = false, want true")
+	}
+
+	h.Record(errors.New("connection refused"))
+	snap := h.Snapshot()
+	if snap.Failures != 1 || snap.LastError != "connection refused" {
+		t.Errorf("after failure: %+v", snap)
+	}
+	if snap.Healthy() {
+		t.Error("Healthy() after failure = true, want false")
+	}
+
+	h.Record(nil)
+	snap = h.Snapshot()
+	if snap.Successes != 1 || !snap.Healthy() {
+		t.Errorf("after recovery: %+v (healthy %v)", snap, snap.Healthy())
+	}
+	if snap.LastError != "connection refused" {
+		t.Errorf("LastError = %q, want the last failure kept", snap.LastError)
+	}
+}
@@ -0,0 +1,284 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WildcardComponent is the component name that addresses the global level
+// in a level spec (e.g. "*=info").
+const WildcardComponent = "*"
+
+// Levels holds the live log levels shared by a root logger and all of its
+// children: the global level, optional per-output overrides, and per-component
+// overrides keyed by logger name ("p2p", "consensus.vote").
+//
+// A component inherits the level of its nearest configured ancestor, falling
+// back to the global level. Outputs with an explicit level ignore component
+// levels; outputs without one follow them.
+//
+// All read paths are lock-free and allocation-free.
+type Levels struct {
+	global     zap.AtomicLevel
+	components atomic.Pointer[componentTable]
+	mu         sync.Mutex // serializes writers of components
+
+	sinks []*SinkLevel
+}
+
+// componentTable is an immutable snapshot of per-component levels.
+// Writers replace the whole table (copy-on-write).
+type componentTable struct {
+	levels map[string]zapcore.Level
+	min    zapcore.Level
+}
+
+// SinkLevel is the level gate of one output (console, file, otel).
+// It is either pinned to its own level or follows the global/component levels.
+type SinkLevel struct {
+	name     string
+	explicit atomic.Bool
+	level    zap.AtomicLevel
+}
+
+// Name returns the output name ("console", "file", "otel").
+func (s *SinkLevel) Name() string { return s.name }
+
+// Level returns the output's own level and whether it is pinned to it.
+// When pinned is false the output follows the global and component levels.
+func (s *SinkLevel) Level() (lvl zapcore.Level, pinned bool) {
+	return s.level.Level(), s.explicit.Load()
+}
+
+// Set pins the output to lvl, independent of global and component levels.
+func (s *SinkLevel) Set(lvl zapcore.Level) {
+	s.level.SetLevel(lvl)
+	s.explicit.Store(true)
+}
+
+// Inherit unpins the output so it follows the global and component levels again.
+func (s *SinkLevel) Inherit() {
+	s.explicit.Store(false)
+}
+
+// NewLevels creates a Levels with the given global level and no overrides.
+func NewLevels(global zap.AtomicLevel) *Levels {
+	return &Levels{global: global}
+}
+
+// Global returns the global level. Changing it affects every logger and
+// every output without an explicit level.
+func (l *Levels) Global() zap.AtomicLevel {
+	return l.global
+}
+
+// AddSink registers an output. If explicit is false the output follows the
+// global and component levels; otherwise it is pinned to level.
+func (l *Levels) AddSink(name string, explicit bool, level zapcore.Level) *SinkLevel {
+	s := &SinkLevel{name: name, level: zap.NewAtomicLevelAt(level)}
+	s.explicit.Store(explicit)
+	l.sinks = append(l.sinks, s)
+	return s
+}
+
+// Sink returns the registered output with the given name, or nil if that
+// output is not enabled.
+func (l *Levels) Sink(name string) *SinkLevel {
+	for _, s := range l.sinks {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Sinks returns the registered outputs in registration order.
+func (l *Levels) Sinks() []*SinkLevel {
+	return l.sinks
+}
+
+// SetComponent sets the level for a logger name and all of its descendants
+// that have no closer override. The wildcard "*" sets the global level.
+func (l *Levels) SetComponent(name string, lvl zapcore.Level) {
+	if name == WildcardComponent {
+		l.global.SetLevel(lvl)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	next := make(map[string]zapcore.Level)
+	if cur := l.components.Load(); cur != nil {
+		for k, v := range cur.levels {
+			next[k] = v
+		}
+	}
+	next[name] = lvl
+	l.components.Store(newComponentTable(next))
+}
+
+// ClearComponent removes the override for a logger name; it then inherits
+// from its nearest configured ancestor again.
+func (l *Levels) ClearComponent(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cur := l.components.Load()
+	if cur == nil {
+		return
+	}
+	if _, ok := cur.levels[name]; !ok {
+		return
+	}
+	next := make(map[string]zapcore.Level, len(cur.levels))
+	for k, v := range cur.levels {
+		if k != name {
+			next[k] = v
+		}
+	}
+	l.components.Store(newComponentTable(next))
+}
+
+// Components returns a copy of the per-component overrides.
+func (l *Levels) Components() map[string]zapcore.Level {
+	out := make(map[string]zapcore.Level)
+	if cur := l.components.Load(); cur != nil {
+		for k, v := range cur.levels {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Effective returns the level that applies to the named logger:
+// the nearest configured ancestor's level, or the global level.
+func (l *Levels) Effective(name string) zapcore.Level {
+	if cur := l.components.Load(); cur != nil {
+		for n := name; n != ""; {
+			if lvl, ok := cur.levels[n]; ok {
+				return lvl
+			}
+			i := strings.LastIndexByte(n, '.')
+			if i < 0 {
+				break
+			}
+			n = n[:i]
+		}
+	}
+	return l.global.Level()
+}
+
+// Enabled reports whether any output would accept an entry at lvl from the
+// named logger. It is the cheap gate checked before fields are prepared.
+func (l *Levels) Enabled(name string, lvl zapcore.Level) bool {
+	if lvl >= l.Effective(name) {
+		return true
+	}
+	for _, s := range l.sinks {
+		if s.explicit.Load() && lvl >= s.level.Level() {
+			return true
+		}
+	}
+	return false
+}
+
+// threshold returns the minimum level the sink accepts from the named logger.
+func (l *Levels) threshold(s *SinkLevel, name string) zapcore.Level {
+	if s.explicit.Load() {
+		return s.level.Level()
+	}
+	return l.Effective(name)
+}
+
+// floor returns the lowest level the sink could accept from any logger.
+func (l *Levels) floor(s *SinkLevel) zapcore.Level {
+	if s.explicit.Load() {
+		return s.level.Level()
+	}
+	lvl := l.global.Level()
+	if cur := l.components.Load(); cur != nil && len(cur.levels) > 0 && cur.min < lvl {
+		lvl = cur.min
+	}
+	return lvl
+}
+
+func newComponentTable(levels map[string]zapcore.Level) *componentTable {
+	t := &componentTable{levels: levels, min: zapcore.InvalidLevel}
+	first := true
+	for _, lvl := range levels {
+		if first || lvl < t.min {
+			t.min = lvl
+			first = false
+		}
+	}
+	return t
+}
+
+// ParseLevelSpec parses a comma-separated list of name=level pairs,
+// e.g. "p2p=debug,consensus.vote=warn,*=info".
+func ParseLevelSpec(spec string) (map[string]zapcore.Level, error) {
+	out := make(map[string]zapcore.Level)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, level, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid level spec entry %q (use: name=level)", part)
+		}
+		lvl, err := ParseLevel(strings.TrimSpace(level))
+		if err != nil {
+			return nil, fmt.Errorf("invalid level spec entry %q: %w", part, err)
+		}
+		out[name] = lvl
+	}
+	return out, nil
+}
+
+// ParseLevel parses a level name, accepting the same names as Config.Level.
+func ParseLevel(level string) (zapcore.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug", "info", "warn", "warning", "error", "fatal":
+		return parseLevel(level), nil
+	default:
+		return zapcore.InvalidLevel, fmt.Errorf("unknown level %q", level)
+	}
+}
+
+// sinkLevelCore gates a single output by the live Levels. Entries are
+// admitted per logger name, so a component override reaches every output
+// that follows the global level.
+type sinkLevelCore struct {
+	zapcore.Core
+	levels *Levels
+	sink   *SinkLevel
+}
+
+// NewSinkLevelCore wraps an output core so it is gated by levels.
+// The wrapped core should accept every level; gating happens here.
+func NewSinkLevelCore(core zapcore.Core, levels *Levels, sink *SinkLevel) zapcore.Core {
+	return &sinkLevelCore{Core: core, levels: levels, sink: sink}
+}
+
+func (c *sinkLevelCore) Enabled(lvl zapcore.Level) bool {
+	return lvl >= c.levels.floor(c.sink)
+}
+
+func (c *sinkLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sinkLevelCore{Core: c.Core.With(fields), levels: c.levels, sink: c.sink}
+}
+
+func (c *sinkLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level >= c.levels.threshold(c.sink, ent.LoggerName) {
+		return c.Core.Check(ent, ce)
+	}
+	return ce
+}
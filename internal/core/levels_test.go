@@ -0,0 +1,106 @@
+package core
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevels_Effective(t *testing.T) {
+	levels := NewLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+	levels.SetComponent("p2p", zapcore.DebugLevel)
+	levels.SetComponent("consensus.vote", zapcore.WarnLevel)
+
+	tests := []struct {
+		name string
+		want zapcore.Level
+	}{
+		{"", zapcore.InfoLevel},
+		{"p2p", zapcore.DebugLevel},
+		{"p2p.gossip", zapcore.DebugLevel},
+		{"p2px", zapcore.InfoLevel},
+		{"consensus", zapcore.InfoLevel},
+		{"consensus.vote", zapcore.WarnLevel},
+		{"consensus.vote.tally", zapcore.WarnLevel},
+	}
+	for _, tt := range tests {
+		if got := levels.Effective(tt.name); got != tt.want {
+			t.Errorf("Effective(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	levels.ClearComponent("p2p")
+	if got := levels.Effective("p2p.gossip"); got != zapcore.InfoLevel {
+		t.Errorf("Effective after clear = %v, want info", got)
+	}
+
+	levels.SetComponent(WildcardComponent, zapcore.ErrorLevel)
+	if got := levels.Global().Level(); got != zapcore.ErrorLevel {
+		t.Errorf("global after \"*\" = %v, want error", got)
+	}
+}
+
+func TestParseLevelSpec(t *testing.T) {
+	spec, err := ParseLevelSpec("p2p=debug, consensus.vote=WARN,*=info")
+	if err != nil {
+		t.Fatalf("ParseLevelSpec() error: %v", err)
+	}
+	want := map[string]zapcore.Level{
+		"p2p":            zapcore.DebugLevel,
+		"consensus.vote": zapcore.WarnLevel,
+		"*":              zapcore.InfoLevel,
+	}
+	for k, v := range want {
+		if spec[k] != v {
+			t.Errorf("spec[%q] = %v, want %v", k, spec[k], v)
+		}
+	}
+
+	for _, bad := range []string{"p2p", "=debug", "p2p=loud"} {
+		if _, err := ParseLevelSpec(bad); err == nil {
+			t.Errorf("ParseLevelSpec(%q) error = nil, want error", bad)
+		}
+	}
+}
+
+func TestSinkLevelCore(t *testing.T) {
+	levels := NewLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+
+	followCore, follow := observer.New(zapcore.DebugLevel)
+	pinnedCore, pinned := observer.New(zapcore.DebugLevel)
+	logger := zap.New(zapcore.NewTee(
+		NewSinkLevelCore(followCore, levels, levels.AddSink("console", false, zapcore.InfoLevel)),
+		NewSinkLevelCore(pinnedCore, levels, levels.AddSink("otel", true, zapcore.ErrorLevel)),
+	))
+
+	levels.SetComponent("p2p", zapcore.DebugLevel)
+
+	logger.Named("p2p").Debug("p2p debug")
+	logger.Named("consensus").Debug("consensus debug")
+	logger.Named("consensus").Error("consensus error")
+
+	if got := follow.FilterMessage("p2p debug").Len(); got != 1 {
+		t.Errorf("following sink got %d p2p debug entries, want 1", got)
+	}
+	if got := follow.FilterMessage("consensus debug").Len(); got != 0 {
+		t.Errorf("following sink got %d consensus debug entries, want 0", got)
+	}
+	if got := pinned.Len(); got != 1 {
+		t.Errorf("pinned sink got %d entries, want 1 (error only)", got)
+	}
+}
+
+func TestLevels_EnabledNoAllocs(t *testing.T) {
+	levels := NewLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+	levels.SetComponent("p2p", zapcore.DebugLevel)
+	levels.SetComponent("consensus.vote", zapcore.WarnLevel)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = levels.Enabled("consensus.vote.tally", zapcore.DebugLevel)
+	})
+	if allocs != 0 {
+		t.Errorf("Enabled allocated %v times per call, want 0", allocs)
+	}
+}
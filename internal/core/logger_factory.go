@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -16,61 +17,40 @@ import (
 type ZapFactoryResult struct {
 	Logger       *zap.Logger
 	AtomicLevel  zap.AtomicLevel
+	Levels       *Levels
 	OTELProvider *LogProvider
+	SpanEvents   *SpanEvents // nil unless Tracing.LogsAsEvents is enabled
 }
 
 // NewZapLogger creates a new configured Zap logger.
-// It sets up console, file, and OTEL cores as configured.
-func NewZapLogger(cfg config.Config) (*ZapFactoryResult, error) {
+// It sets up console, file, and OTEL cores as configured, plus extra.ZapCores.
+// res is the shared OTEL resource from NewResource.
+func NewZapLogger(cfg config.Config, res *resource.Resource, extra Components) (*ZapFactoryResult, error) {
 	var otelProvider *LogProvider
 	var otelCore zapcore.Core
 	var err error
 
-	// Determine global level
-	globalLevel := parseLevel(cfg.Level)
+	// The global level is the master level changed by SetLevel.
+	// Sinks without their own level, and components without an override, follow it.
+	levels := NewLevels(zap.NewAtomicLevelAt(parseLevel(cfg.Level)))
 
-	// Determine sink-specific levels (defaulting to global)
-	consoleLevel := globalLevel
-	if cfg.Console.Level != "" {
-		consoleLevel = parseLevel(cfg.Console.Level)
-	}
-
-	fileLevel := globalLevel
-	if cfg.File.Level != "" {
-		fileLevel = parseLevel(cfg.File.Level)
-	}
-
-	otelLevel := globalLevel
-	if cfg.OTEL.Level != "" {
-		otelLevel = parseLevel(cfg.OTEL.Level)
-	}
-
-	// Calculate the minimum level across all ENABLED sinks.
-	// This ensures the main atomicLevel (used for SetLevel and early filtering)
-	// allows logs to pass if ANY sink needs them.
-	minLevel := globalLevel // Start with global (safe default)
-
-	// If a sink is enabled, check if its level is lower (more verbose)
-	// zapcore.DebugLevel (-1) < zapcore.InfoLevel (0)
-	if cfg.Console.Enabled && consoleLevel < minLevel {
-		minLevel = consoleLevel
-	}
-	if cfg.File.Enabled && fileLevel < minLevel {
-		minLevel = fileLevel
-	}
-	if cfg.OTEL.Enabled && otelLevel < minLevel {
-		minLevel = otelLevel
+	// Per-component overrides ("p2p=debug,*=info")
+	if cfg.ComponentLevels != "" {
+		spec, err := ParseLevelSpec(cfg.ComponentLevels)
+		if err != nil {
+			return nil, fmt.Errorf("invalid component levels: %w", err)
+		}
+		for name, lvl := range spec {
+			levels.SetComponent(name, lvl)
+		}
 	}
 
-	// The atomicLevel acts as the master gatekeeper in logger_impl.go
-	atomicLevel := zap.NewAtomicLevelAt(minLevel)
-
-	// 1. Setup OTEL if enabled
-	if cfg.OTEL.Enabled && cfg.OTEL.Endpoint != "" {
+	// 1. Setup OTEL if enabled (or if the caller supplied log exporters)
+	if (cfg.OTEL.Enabled && cfg.OTEL.Endpoint != "") || len(extra.LogExporters) > 0 {
 		// Inject Basic Auth header if credentials provided
 		cfg.OTEL.Headers = injectBasicAuth(cfg.OTEL.Headers, cfg.OTEL.Username, cfg.OTEL.Password, cfg.OTEL.Protocol)
 
-		otelProvider, err = SetupLogProvider(cfg.OTEL, cfg.ServiceName, cfg.Version)
+		otelProvider, err = SetupLogProvider(cfg.OTEL, res, extra)
 		if err != nil {
 			return nil, fmt.Errorf("otel setup failed: %w", err)
 		}
@@ -83,36 +63,59 @@ func NewZapLogger(cfg config.Config) (*ZapFactoryResult, error) {
 		}
 	}
 
+	// Redaction (per output)
+	redactor, err := NewRedactor(cfg.Redaction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction config: %w", err)
+	}
+	redact := func(c zapcore.Core, output string) zapcore.Core {
+		if !RedactionApplies(cfg.Redaction, output) {
+			return c
+		}
+		return NewRedactingCore(c, redactor)
+	}
+
 	// 2. Build Cores
+	// Each output core accepts every level; the sinkLevelCore wrapper gates it
+	// by the sink's own level (if set) or the live global/component levels.
 	cores := make([]zapcore.Core, 0, 4)
 
 	// Console
 	if cfg.Console.Enabled {
-		// Use specific consoleLevel for console cores
-		consoleCores := buildConsoleCores(cfg, consoleLevel)
-		for _, c := range consoleCores {
-			cores = append(cores, NewFilteringCore(c, SentinelKey))
+		sink := levels.AddSink("console", cfg.Console.Level != "", parseLevel(cfg.Console.Level))
+		for _, c := range buildConsoleCores(cfg, zapcore.DebugLevel) {
+			cores = append(cores, NewSinkLevelCore(NewFilteringCore(redact(c, "console"), SentinelKey), levels, sink))
 		}
 	}
 
 	// File
 	if cfg.File.Enabled && cfg.File.Path != "" {
-		// Use specific fileLevel for file core
-		fileCore := buildFileCore(cfg, fileLevel)
+		fileCore := buildFileCore(cfg, zapcore.DebugLevel)
 		if fileCore != nil {
-			cores = append(cores, NewFilteringCore(fileCore, SentinelKey))
+			sink := levels.AddSink("file", cfg.File.Level != "", parseLevel(cfg.File.Level))
+			cores = append(cores, NewSinkLevelCore(NewFilteringCore(redact(fileCore, "file"), SentinelKey), levels, sink))
 		}
 	}
 
 	// OTEL
 	if otelCore != nil {
-		// Use specific otelLevel for OTEL core
-		otelCore = &levelEnforcer{Core: otelCore, level: otelLevel}
+		// The otelzap core defaults to its own level check; force it open so
+		// the sinkLevelCore wrapper alone decides what is exported.
+		otelCore = &levelEnforcer{Core: otelCore, level: zapcore.DebugLevel}
 
 		// Filter SentinelKey (internal context carrier) but allow trace_id/span_id
 		// to pass through as explicit attributes. This ensures they are present in the
 		// log body/attributes for easy regex extraction and visibility in Loki.
-		cores = append(cores, NewFilteringCore(otelCore, SentinelKey))
+		sink := levels.AddSink("otel", cfg.OTEL.Level != "", parseLevel(cfg.OTEL.Level))
+		cores = append(cores, NewSinkLevelCore(NewFilteringCore(redact(otelCore, "otel"), SentinelKey), levels, sink))
+	}
+
+	// Custom cores supplied by the caller
+	if len(extra.ZapCores) > 0 {
+		sink := levels.AddSink(CustomOutput, false, zapcore.DebugLevel)
+		for _, c := range extra.ZapCores {
+			cores = append(cores, NewSinkLevelCore(NewFilteringCore(redact(c, CustomOutput), SentinelKey), levels, sink))
+		}
 	}
 
 	// 3. Combine
@@ -126,6 +129,9 @@ func NewZapLogger(cfg config.Config) (*ZapFactoryResult, error) {
 		core = zapcore.NewTee(cores...)
 	}
 
+	// Sampling sits in front of every output so they all keep the same entries.
+	core = NewSamplingCore(core, cfg.Sampling)
+
 	// 4. Build options
 	opts := buildZapOptions(cfg)
 
@@ -135,10 +141,19 @@ func NewZapLogger(cfg config.Config) (*ZapFactoryResult, error) {
 
 	logger := zap.New(core, opts...)
 
+	// Span events carry fields to the trace backend, so they follow the
+	// "otel" output's redaction policy.
+	var eventRedactor *Redactor
+	if RedactionApplies(cfg.Redaction, "otel") {
+		eventRedactor = redactor
+	}
+
 	return &ZapFactoryResult{
 		Logger:       logger,
-		AtomicLevel:  atomicLevel,
+		AtomicLevel:  levels.Global(),
+		Levels:       levels,
 		OTELProvider: otelProvider,
+		SpanEvents:   NewSpanEvents(cfg.Tracing.LogsAsEvents, eventRedactor),
 	}, nil
 }
 
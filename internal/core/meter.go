@@ -3,16 +3,21 @@ package core
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"google.golang.org/grpc"
 	insecurecreds "google.golang.org/grpc/credentials/insecure"
 
@@ -22,6 +27,42 @@ import (
 // MeterProvider wraps the OTEL MeterProvider.
 type MeterProvider struct {
 	provider *sdkmetric.MeterProvider
+	health   *ExportHealth
+	queue    *ExportQueue
+	handler  http.Handler // Prometheus scrape handler; nil without a Prometheus reader
+}
+
+// Queue returns the disk queue of the OTLP exporter, or nil if it is not enabled.
+func (mp *MeterProvider) Queue() *ExportQueue {
+	if mp == nil {
+		return nil
+	}
+	return mp.queue
+}
+
+// Handler returns the Prometheus scrape handler, or nil if the Prometheus
+// reader is not enabled.
+func (mp *MeterProvider) Handler() http.Handler {
+	if mp == nil {
+		return nil
+	}
+	return mp.handler
+}
+
+// Health returns the export health of the metric exporter.
+func (mp *MeterProvider) Health() *ExportHealth {
+	if mp == nil {
+		return nil
+	}
+	return mp.health
+}
+
+// MeterProvider returns the underlying sdkmetric.MeterProvider.
+func (mp *MeterProvider) MeterProvider() *sdkmetric.MeterProvider {
+	if mp == nil {
+		return nil
+	}
+	return mp.provider
 }
 
 // Meter returns a named meter.
@@ -32,6 +73,22 @@ func (mp *MeterProvider) Meter(name string, opts ...metric.MeterOption) metric.M
 	return mp.provider.Meter(name, opts...)
 }
 
+// ForceFlush collects and exports metrics from all push readers.
+func (mp *MeterProvider) ForceFlush(ctx context.Context) error {
+	if mp == nil || mp.provider == nil {
+		return nil
+	}
+	return mp.provider.ForceFlush(ctx)
+}
+
+// SetGlobal installs the provider as the global OTEL meter provider.
+func (mp *MeterProvider) SetGlobal() {
+	if mp == nil || mp.provider == nil {
+		return
+	}
+	otel.SetMeterProvider(mp.provider)
+}
+
 // Shutdown shuts down the meter provider.
 func (mp *MeterProvider) Shutdown(ctx context.Context) error {
 	if mp == nil || mp.provider == nil {
@@ -41,36 +98,84 @@ func (mp *MeterProvider) Shutdown(ctx context.Context) error {
 }
 
 // SetupMeterProvider initializes OpenTelemetry metrics.
-func SetupMeterProvider(cfg config.MetricsConfig, serviceName, version string) (*MeterProvider, error) {
-	if !cfg.Enabled || (cfg.Endpoint == "" && cfg.Protocol == "") {
+// The OTLP exporter is built only when cfg is enabled with an endpoint or
+// protocol; extra.MetricReaders are added either way. Returns nil if there is
+// neither.
+func SetupMeterProvider(cfg config.MetricsConfig, res *resource.Resource, extra Components) (*MeterProvider, error) {
+	exportOTLP := cfg.Enabled && (cfg.Endpoint != "" || cfg.Protocol != "") && cfg.Protocol != "prometheus"
+	pull := cfg.Enabled && (cfg.Protocol == "prometheus" || cfg.Prometheus)
+	if !exportOTLP && !pull && len(extra.MetricReaders) == 0 {
 		return nil, nil
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	res, err := resource.New(ctx,
-		resource.WithHost(),
-		resource.WithOS(),
-		resource.WithProcess(),
-		resource.WithAttributes(semconv.ServiceName(serviceName), semconv.ServiceVersion(version)),
-	)
+	res, err := signalResource(res, cfg.Attributes, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+
+	var health *ExportHealth
+	var queue *ExportQueue
+	if exportOTLP {
+		reader, h, q, err := newOTLPMetricReader(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		health, queue = h, q
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+	var handler http.Handler
+	if pull {
+		reader, h, err := newPrometheusReader()
+		if err != nil {
+			return nil, err
+		}
+		handler = h
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+	for _, r := range extra.MetricReaders {
+		opts = append(opts, sdkmetric.WithReader(r))
+	}
+
+	// Provider
+	mp := sdkmetric.NewMeterProvider(opts...)
+
+	return &MeterProvider{provider: mp, health: health, queue: queue, handler: handler}, nil
+}
+
+// newPrometheusReader builds a Prometheus pull reader on its own registry, so
+// instances in one process do not share series. Resource attributes are
+// exposed as the labels of the target_info metric.
+func newPrometheusReader() (sdkmetric.Reader, http.Handler, error) {
+	registry := prometheus.NewRegistry()
+	reader, err := otelprom.New(otelprom.WithRegisterer(registry))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create OTEL resource: %w", err)
+		return nil, nil, fmt.Errorf("failed to create prometheus reader: %w", err)
 	}
+	return reader, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), nil
+}
 
+// newOTLPMetricReader builds the periodic OTLP metric reader for cfg, and
+// its disk queue when cfg.Queue is enabled.
+func newOTLPMetricReader(ctx context.Context, cfg config.MetricsConfig) (sdkmetric.Reader, *ExportHealth, *ExportQueue, error) {
 	// Inject Basic Auth header if credentials provided
 	headers := injectBasicAuth(cfg.Headers, cfg.Username, cfg.Password, cfg.Protocol)
 
 	// Parse/Sanitize endpoint
 	endpoint, insecure, err := processEndpoint(cfg.Endpoint, cfg.Insecure)
 	if err != nil {
-		return nil, fmt.Errorf("invalid metrics endpoint: %w", err)
+		return nil, nil, nil, fmt.Errorf("invalid metrics endpoint: %w", err)
 	}
 
 	// Exporter
 	var exporter sdkmetric.Exporter
 	switch cfg.Protocol {
+	case "stdout", "file":
+		exporter = newOTLPJSONMetricExporter(cfg)
 	case "http":
 		opts := []otlpmetrichttp.Option{
 			otlpmetrichttp.WithEndpoint(endpoint),
@@ -84,6 +189,7 @@ func SetupMeterProvider(cfg config.MetricsConfig, serviceName, version string) (
 		if cfg.Timeout > 0 {
 			opts = append(opts, otlpmetrichttp.WithTimeout(cfg.Timeout))
 		}
+		opts = append(opts, otlpmetrichttp.WithTemporalitySelector(temporalitySelector(cfg.Temporality)))
 		exporter, err = otlpmetrichttp.New(ctx, opts...)
 	default:
 		// Default to gRPC
@@ -100,10 +206,21 @@ func SetupMeterProvider(cfg config.MetricsConfig, serviceName, version string) (
 		if cfg.Timeout > 0 {
 			opts = append(opts, otlpmetricgrpc.WithTimeout(cfg.Timeout))
 		}
+		opts = append(opts, otlpmetricgrpc.WithTemporalitySelector(temporalitySelector(cfg.Temporality)))
 		exporter, err = otlpmetricgrpc.New(ctx, opts...)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create metric exporter: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to create metric exporter: %w", err)
+	}
+	health := &ExportHealth{}
+	exporter = &healthMetricExporter{Exporter: exporter, health: health}
+	var queue *ExportQueue
+	if cfg.Queue.Enabled && !config.IsLocalProtocol(cfg.Protocol) {
+		queued, err := newQueuedMetricExporter(exporter, cfg.Queue)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		exporter, queue = queued, queued.queue
 	}
 
 	// Reader
@@ -112,20 +229,28 @@ func SetupMeterProvider(cfg config.MetricsConfig, serviceName, version string) (
 		interval = 15 * time.Second
 	}
 
-	// Default to Cumulative temporality (default OTel behavior)
+	// Temporality is chosen by the exporter's selector above
 	reader := sdkmetric.NewPeriodicReader(
 		exporter,
 		sdkmetric.WithInterval(interval),
 	)
+	return reader, health, queue, nil
+}
 
-	// Provider
-	mp := sdkmetric.NewMeterProvider(
-		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(reader),
-	)
-
-	// Set global provider
-	otel.SetMeterProvider(mp)
-
-	return &MeterProvider{provider: mp}, nil
+// temporalitySelector maps MetricsConfig.Temporality to an exporter selector.
+// "delta" reports counters and histograms as deltas but keeps up-down counters
+// cumulative, since a delta of a non-monotonic sum is rarely meaningful.
+// Anything else uses the OTel default (cumulative for all instruments).
+func temporalitySelector(temporality string) sdkmetric.TemporalitySelector {
+	if temporality != "delta" {
+		return sdkmetric.DefaultTemporalitySelector
+	}
+	return func(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+		switch kind {
+		case sdkmetric.InstrumentKindUpDownCounter, sdkmetric.InstrumentKindObservableUpDownCounter:
+			return metricdata.CumulativeTemporality
+		default:
+			return metricdata.DeltaTemporality
+		}
+	}
 }
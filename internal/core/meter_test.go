@@ -0,0 +1,135 @@
+package core
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+func TestTemporalitySelector(t *testing.T) {
+	tests := []struct {
+		temporality string
+		kind        sdkmetric.InstrumentKind
+		want        metricdata.Temporality
+	}{
+		{"", sdkmetric.InstrumentKindCounter, metricdata.CumulativeTemporality},
+		{"cumulative", sdkmetric.InstrumentKindHistogram, metricdata.CumulativeTemporality},
+		{"delta", sdkmetric.InstrumentKindCounter, metricdata.DeltaTemporality},
+		{"delta", sdkmetric.InstrumentKindHistogram, metricdata.DeltaTemporality},
+		{"delta", sdkmetric.InstrumentKindObservableCounter, metricdata.DeltaTemporality},
+		{"delta", sdkmetric.InstrumentKindUpDownCounter, metricdata.CumulativeTemporality},
+		{"delta", sdkmetric.InstrumentKindObservableUpDownCounter, metricdata.CumulativeTemporality},
+	}
+
+	for _, tt := range tests {
+		got := temporalitySelector(tt.temporality)(tt.kind)
+		if got != tt.want {
+			t.Errorf("temporalitySelector(%q)(%v) = %v, want %v", tt.temporality, tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestNewResource(t *testing.T) {
+	res, err := NewResource(context.Background(), "bridge", "1.2.3", map[string]string{
+		"environment": "staging",
+	})
+	if err != nil {
+		t.Fatalf("NewResource() error: %v", err)
+	}
+
+	want := map[attribute.Key]string{
+		semconv.ServiceNameKey:    "bridge",
+		semconv.ServiceVersionKey: "1.2.3",
+		"environment":             "staging",
+	}
+	set := res.Set()
+	for k, v := range want {
+		got, ok := set.Value(k)
+		if !ok || got.AsString() != v {
+			t.Errorf("resource[%s] = %q, want %q", k, got.AsString(), v)
+		}
+	}
+	if _, ok := set.Value(semconv.HostNameKey); !ok {
+		t.Error("resource missing host.name")
+	}
+	if _, ok := set.Value(semconv.ProcessPIDKey); !ok {
+		t.Error("resource missing process.pid")
+	}
+}
+
+func TestSignalResource(t *testing.T) {
+	base, err := NewResource(context.Background(), "bridge", "1.2.3", nil)
+	if err != nil {
+		t.Fatalf("NewResource() error: %v", err)
+	}
+	extra := Components{Resource: resource.NewWithAttributes("https://example.com/schema",
+		attribute.String("environment", "prod"),
+		attribute.String("k8s.pod.name", "bridge-0"),
+	)}
+	res, err := signalResource(base, map[string]string{
+		"environment": "staging",
+	}, extra)
+	if err != nil {
+		t.Fatalf("signalResource() error: %v", err)
+	}
+
+	want := map[attribute.Key]string{
+		semconv.ServiceNameKey: "bridge",
+		"environment":          "prod",
+		"k8s.pod.name":         "bridge-0",
+	}
+	set := res.Set()
+	for k, v := range want {
+		got, ok := set.Value(k)
+		if !ok || got.AsString() != v {
+			t.Errorf("resource[%s] = %q, want %q", k, got.AsString(), v)
+		}
+	}
+	// Detected attributes come from base, not a second detection run.
+	wantHost, _ := base.Set().Value(semconv.HostNameKey)
+	if got, _ := set.Value(semconv.HostNameKey); got != wantHost {
+		t.Errorf("resource[host.name] = %q, want %q", got.AsString(), wantHost.AsString())
+	}
+}
+
+func TestSetupMeterProvider_PrometheusAlongsidePush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	cfg := config.MetricsConfig{
+		Enabled:    true,
+		Protocol:   "file",
+		Prometheus: true,
+		File:       config.FileConfig{Path: path, MaxSizeMB: 1},
+	}
+	mp, err := SetupMeterProvider(cfg, nil, Components{})
+	if err != nil {
+		t.Fatalf("SetupMeterProvider() error: %v", err)
+	}
+	defer func() { _ = mp.Shutdown(context.Background()) }()
+
+	counter, _ := mp.Meter("test").Int64Counter("blocks.applied")
+	counter.Add(context.Background(), 3)
+
+	rec := httptest.NewRecorder()
+	mp.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if body := rec.Body.String(); !strings.Contains(body, "blocks_applied_total") {
+		t.Errorf("scrape = %s, want blocks_applied_total", body)
+	}
+
+	if err := mp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "blocks.applied") {
+		t.Errorf("push file = %s, want blocks.applied", data)
+	}
+}
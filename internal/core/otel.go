@@ -5,14 +5,13 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/log/global"
@@ -20,7 +19,7 @@ import (
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	insecurecreds "google.golang.org/grpc/credentials/insecure"
 
@@ -30,6 +29,24 @@ import (
 // LogProvider manages the OpenTelemetry log provider.
 type LogProvider struct {
 	loggerProvider *sdklog.LoggerProvider
+	health         *ExportHealth
+	queue          *ExportQueue
+}
+
+// Queue returns the disk queue of the OTLP exporter, or nil if it is not enabled.
+func (p *LogProvider) Queue() *ExportQueue {
+	if p == nil {
+		return nil
+	}
+	return p.queue
+}
+
+// Health returns the export health of the log exporter.
+func (p *LogProvider) Health() *ExportHealth {
+	if p == nil {
+		return nil
+	}
+	return p.health
 }
 
 // LoggerProvider returns the underlying sdklog.LoggerProvider
@@ -40,6 +57,22 @@ func (p *LogProvider) LoggerProvider() *sdklog.LoggerProvider {
 	return p.loggerProvider
 }
 
+// ForceFlush exports all buffered log records.
+func (p *LogProvider) ForceFlush(ctx context.Context) error {
+	if p == nil || p.loggerProvider == nil {
+		return nil
+	}
+	return p.loggerProvider.ForceFlush(ctx)
+}
+
+// SetGlobal installs the provider as the global OTEL logger provider.
+func (p *LogProvider) SetGlobal() {
+	if p == nil || p.loggerProvider == nil {
+		return
+	}
+	global.SetLoggerProvider(p.loggerProvider)
+}
+
 // Shutdown shuts down the log provider.
 func (p *LogProvider) Shutdown(ctx context.Context) error {
 	if p == nil || p.loggerProvider == nil {
@@ -50,7 +83,76 @@ func (p *LogProvider) Shutdown(ctx context.Context) error {
 
 // TracerProvider wraps the OTEL TracerProvider.
 type TracerProvider struct {
-	provider *sdktrace.TracerProvider
+	provider   *sdktrace.TracerProvider
+	propagator propagation.TextMapPropagator
+	health     *ExportHealth
+	tail       *TailSampler
+	queue      *ExportQueue
+}
+
+// Queue returns the disk queue of the OTLP exporter, or nil if it is not enabled.
+func (tp *TracerProvider) Queue() *ExportQueue {
+	if tp == nil {
+		return nil
+	}
+	return tp.queue
+}
+
+// TailSampler returns the tail sampler, or nil if tail sampling is disabled.
+func (tp *TracerProvider) TailSampler() *TailSampler {
+	if tp == nil {
+		return nil
+	}
+	return tp.tail
+}
+
+// Health returns the export health of the span exporter.
+func (tp *TracerProvider) Health() *ExportHealth {
+	if tp == nil {
+		return nil
+	}
+	return tp.health
+}
+
+// Propagator returns the composite propagator built from TracingConfig.Propagators.
+func (tp *TracerProvider) Propagator() propagation.TextMapPropagator {
+	if tp == nil || tp.propagator == nil {
+		return otel.GetTextMapPropagator()
+	}
+	return tp.propagator
+}
+
+// TracerProvider returns the underlying sdktrace.TracerProvider.
+func (tp *TracerProvider) TracerProvider() *sdktrace.TracerProvider {
+	if tp == nil {
+		return nil
+	}
+	return tp.provider
+}
+
+// Tracer returns a named tracer from this provider.
+func (tp *TracerProvider) Tracer(name string) trace.Tracer {
+	if tp == nil || tp.provider == nil {
+		return otel.Tracer(name)
+	}
+	return tp.provider.Tracer(name)
+}
+
+// ForceFlush exports all ended spans, including those held by the tail sampler.
+func (tp *TracerProvider) ForceFlush(ctx context.Context) error {
+	if tp == nil || tp.provider == nil {
+		return nil
+	}
+	return tp.provider.ForceFlush(ctx)
+}
+
+// SetGlobal installs the provider and propagator as the OTEL globals.
+func (tp *TracerProvider) SetGlobal() {
+	if tp == nil || tp.provider == nil {
+		return
+	}
+	otel.SetTracerProvider(tp.provider)
+	otel.SetTextMapPropagator(tp.propagator)
 }
 
 // Shutdown shuts down the tracer provider.
@@ -62,45 +164,83 @@ func (tp *TracerProvider) Shutdown(ctx context.Context) error {
 }
 
 // SetupLogProvider initializes OpenTelemetry logging.
-func SetupLogProvider(cfg config.OTELConfig, serviceName, version string) (*LogProvider, error) {
-	if !cfg.Enabled || cfg.Endpoint == "" {
+// The OTLP exporter is built only when cfg is enabled with an endpoint;
+// extra.LogExporters are added either way. Returns nil if there is neither.
+// With cfg.Queue enabled, the OTLP exporter stores failed batches on disk.
+func SetupLogProvider(cfg config.OTELConfig, res *resource.Resource, extra Components) (*LogProvider, error) {
+	exportOTLP := cfg.Enabled && cfg.Endpoint != ""
+	if !exportOTLP && len(extra.LogExporters) == 0 {
 		return nil, nil
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Resources
-	attrs := []attribute.KeyValue{
-		semconv.ServiceName(serviceName),
-		semconv.ServiceVersion(version),
+	res, err := signalResource(res, cfg.Attributes, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &ExportHealth{}
+	exporters := make([]sdklog.Exporter, 0, len(extra.LogExporters)+1)
+	var queue *ExportQueue
+	if exportOTLP {
+		exporter, err := newOTLPLogExporter(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		exporter = &healthLogExporter{Exporter: exporter, health: health}
+		if cfg.Queue.Enabled {
+			queued, err := newQueuedLogExporter(exporter, cfg.Queue)
+			if err != nil {
+				return nil, err
+			}
+			exporter, queue = queued, queued.queue
+		}
+		exporters = append(exporters, exporter)
 	}
-	for k, v := range cfg.Attributes {
-		attrs = append(attrs, attribute.String(k, v))
+	for _, exporter := range extra.LogExporters {
+		exporters = append(exporters, &healthLogExporter{Exporter: exporter, health: health})
 	}
 
-	res, err := resource.New(ctx,
-		resource.WithHost(),
-		resource.WithOS(),
-		resource.WithProcess(),
-		resource.WithAttributes(attrs...),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create OTEL resource: %w", err)
+	// Processors
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = 512
+	}
+	exportInterval := cfg.ExportInterval
+	if exportInterval <= 0 {
+		exportInterval = 5 * time.Second
+	}
+
+	opts := []sdklog.LoggerProviderOption{sdklog.WithResource(res)}
+	for _, exporter := range exporters {
+		processor := sdklog.NewBatchProcessor(
+			exporter,
+			sdklog.WithMaxQueueSize(batchSize*2),
+			sdklog.WithExportMaxBatchSize(batchSize),
+			sdklog.WithExportInterval(exportInterval),
+		)
+		opts = append(opts, sdklog.WithProcessor(processor))
 	}
 
-	// Exporter
+	provider := sdklog.NewLoggerProvider(opts...)
+
+	return &LogProvider{loggerProvider: provider, health: health, queue: queue}, nil
+}
+
+// newOTLPLogExporter builds the OTLP log exporter for cfg.
+func newOTLPLogExporter(ctx context.Context, cfg config.OTELConfig) (sdklog.Exporter, error) {
 	// Parse/Sanitize endpoint
 	endpoint, insecure, err := processEndpoint(cfg.Endpoint, cfg.Insecure)
 	if err != nil {
 		return nil, fmt.Errorf("invalid OTEL endpoint: %w", err)
 	}
 
-	var exporter sdklog.Exporter
-
 	// Inject Basic Auth header if credentials provided
 	cfg.Headers = injectBasicAuth(cfg.Headers, cfg.Username, cfg.Password, cfg.Protocol)
 
+	var exporter sdklog.Exporter
 	switch cfg.Protocol {
 	case "http":
 		exporter, err = createHTTPLogExporter(ctx, endpoint, insecure, cfg)
@@ -110,80 +250,127 @@ func SetupLogProvider(cfg config.OTELConfig, serviceName, version string) (*LogP
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTEL log exporter: %w", err)
 	}
+	return exporter, nil
+}
 
-	// Processor
-	batchSize := cfg.BatchSize
-	if batchSize <= 0 {
-		batchSize = 512
+// SetupTracerProvider creates and configures the OTEL tracer provider.
+// The OTLP exporter is built only when cfg.Enabled; extra span exporters and
+// processors are added either way. Returns nil if there are none of these.
+// With cfg.Queue enabled, the OTLP exporter stores failed batches on disk.
+func SetupTracerProvider(cfg config.TracingConfig, res *resource.Resource, extra Components) (*TracerProvider, error) {
+	if !cfg.Enabled && len(extra.SpanProcessors) == 0 && len(extra.SpanExporters) == 0 {
+		return nil, nil
 	}
-	exportInterval := cfg.ExportInterval
-	if exportInterval <= 0 {
-		exportInterval = 5 * time.Second
+
+	// Propagators
+	propagator, err := NewPropagator(cfg.Propagators)
+	if err != nil {
+		return nil, fmt.Errorf("invalid propagators: %w", err)
 	}
 
-	processor := sdklog.NewBatchProcessor(
-		exporter,
-		sdklog.WithMaxQueueSize(batchSize*2),
-		sdklog.WithExportMaxBatchSize(batchSize),
-		sdklog.WithExportInterval(exportInterval),
-	)
+	// Sampler
+	sampler, err := NewSampler(cfg.Sampler, cfg.SamplerRules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sampler: %w", err)
+	}
 
-	provider := sdklog.NewLoggerProvider(
-		sdklog.WithResource(res),
-		sdklog.WithProcessor(processor),
-	)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	// Set global logger provider (optional, but good for libs using global API)
-	global.SetLoggerProvider(provider)
+	res, err = signalResource(res, cfg.Attributes, extra)
+	if err != nil {
+		return nil, err
+	}
 
-	return &LogProvider{loggerProvider: provider}, nil
-}
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sampler),
+	}
+	if len(cfg.BaggageToLogs) > 0 {
+		// Registered first so later processors see the attributes at start.
+		opts = append(opts, sdktrace.WithSpanProcessor(NewBaggageProcessor(cfg.BaggageToLogs)))
+	}
 
-// SetupTracerProvider creates and configures the OTEL tracer provider.
-func SetupTracerProvider(cfg config.TracingConfig, serviceName, version string) (*TracerProvider, error) {
-	if !cfg.Enabled {
-		return nil, nil
+	health := &ExportHealth{}
+	var tail *TailSampler
+	var queue *ExportQueue
+	if cfg.Enabled {
+		exporter, q, err := newOTLPSpanExporter(ctx, cfg, health)
+		if err != nil {
+			return nil, err
+		}
+		queue = q
+		exportHealth := health
+		if queue != nil {
+			// The queued client records its own sends; a stored batch is not a success.
+			exportHealth = nil
+		}
+		var processor sdktrace.SpanProcessor = newBatchSpanProcessor(exporter, exportHealth, cfg)
+		if cfg.TailSampling.Enabled {
+			tail = NewTailSampler(processor, cfg.TailSampling)
+			processor = tail
+		}
+		opts = append(opts, sdktrace.WithSpanProcessor(processor))
+	}
+	// Caller exporters and processors see every sampled span; tail sampling
+	// applies to OTLP only.
+	for _, exporter := range extra.SpanExporters {
+		opts = append(opts, sdktrace.WithSpanProcessor(newBatchSpanProcessor(exporter, health, cfg)))
+	}
+	for _, p := range extra.SpanProcessors {
+		opts = append(opts, sdktrace.WithSpanProcessor(p))
 	}
 
-	// Inject Basic Auth header if credentials provided
-	cfg.Headers = injectBasicAuth(cfg.Headers, cfg.Username, cfg.Password, cfg.Protocol)
+	tp := sdktrace.NewTracerProvider(opts...)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	return &TracerProvider{provider: tp, propagator: propagator, health: health, tail: tail, queue: queue}, nil
+}
 
-	// Resource
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(serviceName),
-			semconv.ServiceVersion(version),
-		),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create resource: %w", err)
+// newOTLPSpanExporter builds the OTLP span exporter for cfg. With
+// cfg.Queue enabled, uploads go through a disk queue that records each send
+// in health, and the queue is returned too.
+func newOTLPSpanExporter(ctx context.Context, cfg config.TracingConfig, health *ExportHealth) (sdktrace.SpanExporter, *ExportQueue, error) {
+	if config.IsLocalProtocol(cfg.Protocol) {
+		return newOTLPJSONSpanExporter(cfg), nil, nil
 	}
 
-	// Exporter
+	// Inject Basic Auth header if credentials provided
+	cfg.Headers = injectBasicAuth(cfg.Headers, cfg.Username, cfg.Password, cfg.Protocol)
+
 	// Parse/Sanitize endpoint
 	endpoint, insecure, err := processEndpoint(cfg.Endpoint, cfg.Insecure)
 	if err != nil {
-		return nil, fmt.Errorf("invalid OTEL endpoint: %w", err)
+		return nil, nil, fmt.Errorf("invalid OTEL endpoint: %w", err)
 	}
 
-	var exporter sdktrace.SpanExporter
+	var client otlptrace.Client
 	switch cfg.Protocol {
 	case "http":
-		exporter, err = createHTTPTraceExporter(ctx, endpoint, insecure, cfg)
+		client = createHTTPTraceClient(endpoint, insecure, cfg)
 	default:
-		exporter, err = createGRPCTraceExporter(ctx, endpoint, insecure, cfg)
+		client = createGRPCTraceClient(endpoint, insecure, cfg)
 	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+
+	var queue *ExportQueue
+	if cfg.Queue.Enabled {
+		queued, err := newQueuedTraceClient(&healthTraceClient{Client: client, health: health}, cfg.Queue)
+		if err != nil {
+			return nil, nil, err
+		}
+		client, queue = queued, queued.queue
 	}
 
-	// Sampler
-	sampler := parseSampler(cfg.Sampler)
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		queue.Close()
+		return nil, nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+	return exporter, queue, nil
+}
 
-	// Processor
+// newBatchSpanProcessor batches spans for exporter using cfg's batch
+// settings, recording export outcomes in health.
+func newBatchSpanProcessor(exporter sdktrace.SpanExporter, health *ExportHealth, cfg config.TracingConfig) sdktrace.SpanProcessor {
 	batchSize := cfg.BatchSize
 	if batchSize <= 0 {
 		batchSize = 512
@@ -193,25 +380,10 @@ func SetupTracerProvider(cfg config.TracingConfig, serviceName, version string)
 		exportInterval = 5 * time.Second
 	}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithResource(res),
-		sdktrace.WithBatcher(exporter,
-			sdktrace.WithMaxExportBatchSize(batchSize),
-			sdktrace.WithBatchTimeout(exportInterval),
-		),
-		sdktrace.WithSampler(sampler),
+	return sdktrace.NewBatchSpanProcessor(&healthSpanExporter{SpanExporter: exporter, health: health},
+		sdktrace.WithMaxExportBatchSize(batchSize),
+		sdktrace.WithBatchTimeout(exportInterval),
 	)
-
-	// Set globals
-	otel.SetTracerProvider(tp)
-
-	props := []propagation.TextMapPropagator{
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	}
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(props...))
-
-	return &TracerProvider{provider: tp}, nil
 }
 
 // --- Helpers ---
@@ -249,7 +421,7 @@ func createHTTPLogExporter(ctx context.Context, endpoint string, insecure bool,
 	return otlploghttp.New(ctx, opts...)
 }
 
-func createGRPCTraceExporter(ctx context.Context, endpoint string, insecure bool, cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+func createGRPCTraceClient(endpoint string, insecure bool, cfg config.TracingConfig) otlptrace.Client {
 	opts := []otlptracegrpc.Option{
 		otlptracegrpc.WithEndpoint(endpoint),
 	}
@@ -263,10 +435,10 @@ func createGRPCTraceExporter(ctx context.Context, endpoint string, insecure bool
 	if cfg.Timeout > 0 {
 		opts = append(opts, otlptracegrpc.WithTimeout(cfg.Timeout))
 	}
-	return otlptracegrpc.New(ctx, opts...)
+	return otlptracegrpc.NewClient(opts...)
 }
 
-func createHTTPTraceExporter(ctx context.Context, endpoint string, insecure bool, cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+func createHTTPTraceClient(endpoint string, insecure bool, cfg config.TracingConfig) otlptrace.Client {
 	opts := []otlptracehttp.Option{
 		otlptracehttp.WithEndpoint(endpoint),
 	}
@@ -279,25 +451,7 @@ func createHTTPTraceExporter(ctx context.Context, endpoint string, insecure bool
 	if cfg.Timeout > 0 {
 		opts = append(opts, otlptracehttp.WithTimeout(cfg.Timeout))
 	}
-	return otlptracehttp.New(ctx, opts...)
-}
-
-func parseSampler(s string) sdktrace.Sampler {
-	switch {
-	case s == "" || s == "always":
-		return sdktrace.AlwaysSample()
-	case s == "never":
-		return sdktrace.NeverSample()
-	case strings.HasPrefix(s, "ratio:"):
-		ratioStr := strings.TrimPrefix(s, "ratio:")
-		ratio, err := strconv.ParseFloat(ratioStr, 64)
-		if err != nil {
-			return sdktrace.AlwaysSample()
-		}
-		return sdktrace.TraceIDRatioBased(ratio)
-	default:
-		return sdktrace.AlwaysSample()
-	}
+	return otlptracehttp.NewClient(opts...)
 }
 
 // processEndpoint parses the endpoint URL to determine the host:port and insecure setting.
@@ -0,0 +1,437 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// Conversions used by the export queue: log records to OTLP, and OTLP logs
+// and metrics back to SDK data for replay through the original exporter.
+// Spans need no conversion back; the queue stores what the OTLP client sends.
+
+// --- Logs to OTLP ---
+
+// resourceLogs groups records by resource and instrumentation scope, keeping
+// the order in which each group is first seen.
+func resourceLogs(records []sdklog.Record) []*logspb.ResourceLogs {
+	type scopeKey struct {
+		res   attribute.Distinct
+		scope instrumentation.Scope
+	}
+	var out []*logspb.ResourceLogs
+	byRes := make(map[attribute.Distinct]*logspb.ResourceLogs)
+	byScope := make(map[scopeKey]*logspb.ScopeLogs)
+
+	for i := range records {
+		r := &records[i]
+		res := r.Resource()
+		resKey := res.Equivalent()
+		rl, ok := byRes[resKey]
+		if !ok {
+			rl = &logspb.ResourceLogs{Resource: resourceProto(res), SchemaUrl: res.SchemaURL()}
+			byRes[resKey] = rl
+			out = append(out, rl)
+		}
+		key := scopeKey{res: resKey, scope: r.InstrumentationScope()}
+		sl, ok := byScope[key]
+		if !ok {
+			sl = &logspb.ScopeLogs{Scope: scopeProto(key.scope), SchemaUrl: key.scope.SchemaURL}
+			byScope[key] = sl
+			rl.ScopeLogs = append(rl.ScopeLogs, sl)
+		}
+		sl.LogRecords = append(sl.LogRecords, logRecordProto(r))
+	}
+	return out
+}
+
+func logRecordProto(r *sdklog.Record) *logspb.LogRecord {
+	lr := &logspb.LogRecord{
+		TimeUnixNano:           unixNano(r.Timestamp()),
+		ObservedTimeUnixNano:   unixNano(r.ObservedTimestamp()),
+		SeverityNumber:         logspb.SeverityNumber(r.Severity()), //nolint:gosec // Severities are 0-24
+		SeverityText:           r.SeverityText(),
+		DroppedAttributesCount: count32(r.DroppedAttributes()),
+		Flags:                  uint32(r.TraceFlags()),
+		EventName:              r.EventName(),
+	}
+	if body := r.Body(); !body.Empty() {
+		lr.Body = logValueProto(body)
+	}
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		lr.Attributes = append(lr.Attributes, &commonpb.KeyValue{Key: kv.Key, Value: logValueProto(kv.Value)})
+		return true
+	})
+	if tid := r.TraceID(); tid.IsValid() {
+		lr.TraceId = tid[:]
+	}
+	if sid := r.SpanID(); sid.IsValid() {
+		lr.SpanId = sid[:]
+	}
+	return lr
+}
+
+func logValueProto(v log.Value) *commonpb.AnyValue {
+	switch v.Kind() {
+	case log.KindBool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case log.KindInt64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case log.KindFloat64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case log.KindString:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.AsString()}}
+	case log.KindBytes:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v.AsBytes()}}
+	case log.KindSlice:
+		arr := &commonpb.ArrayValue{}
+		for _, item := range v.AsSlice() {
+			arr.Values = append(arr.Values, logValueProto(item))
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: arr}}
+	case log.KindMap:
+		kvs := &commonpb.KeyValueList{}
+		for _, kv := range v.AsMap() {
+			kvs.Values = append(kvs.Values, &commonpb.KeyValue{Key: kv.Key, Value: logValueProto(kv.Value)})
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: kvs}}
+	default:
+		return &commonpb.AnyValue{}
+	}
+}
+
+// --- OTLP to logs ---
+
+// logRecords rebuilds SDK records from their OTLP form. Records can only be
+// created by a LoggerProvider, so each resource gets a throwaway provider
+// whose processor captures what is emitted; limits are off so nothing is
+// truncated twice.
+func logRecords(rls []*logspb.ResourceLogs) []sdklog.Record {
+	var out []sdklog.Record
+	for _, rl := range rls {
+		capture := &recordCapture{}
+		provider := sdklog.NewLoggerProvider(
+			sdklog.WithResource(resourceFromProto(rl.GetResource(), rl.GetSchemaUrl())),
+			sdklog.WithProcessor(capture),
+			sdklog.WithAttributeCountLimit(-1),
+			sdklog.WithAttributeValueLengthLimit(-1),
+		)
+		for _, sl := range rl.GetScopeLogs() {
+			scope := sl.GetScope()
+			logger := provider.Logger(scope.GetName(),
+				log.WithInstrumentationVersion(scope.GetVersion()),
+				log.WithSchemaURL(sl.GetSchemaUrl()),
+				log.WithInstrumentationAttributes(attributeKVs(scope.GetAttributes())...),
+			)
+			for _, lr := range sl.GetLogRecords() {
+				logger.Emit(context.Background(), logRecordAPI(lr))
+				r := &capture.records[len(capture.records)-1]
+				var tid trace.TraceID
+				var sid trace.SpanID
+				copy(tid[:], lr.GetTraceId())
+				copy(sid[:], lr.GetSpanId())
+				r.SetTraceID(tid)
+				r.SetSpanID(sid)
+				r.SetTraceFlags(trace.TraceFlags(lr.GetFlags())) //nolint:gosec // Only the low byte holds flags
+			}
+		}
+		out = append(out, capture.records...)
+	}
+	return out
+}
+
+func logRecordAPI(lr *logspb.LogRecord) log.Record {
+	var r log.Record
+	r.SetTimestamp(fromUnixNano(lr.GetTimeUnixNano()))
+	r.SetObservedTimestamp(fromUnixNano(lr.GetObservedTimeUnixNano()))
+	r.SetSeverity(log.Severity(lr.GetSeverityNumber()))
+	r.SetSeverityText(lr.GetSeverityText())
+	r.SetEventName(lr.GetEventName())
+	r.SetBody(logValue(lr.GetBody()))
+	for _, kv := range lr.GetAttributes() {
+		r.AddAttributes(log.KeyValue{Key: kv.GetKey(), Value: logValue(kv.GetValue())})
+	}
+	return r
+}
+
+func logValue(v *commonpb.AnyValue) log.Value {
+	switch x := v.GetValue().(type) {
+	case *commonpb.AnyValue_BoolValue:
+		return log.BoolValue(x.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return log.Int64Value(x.IntValue)
+	case *commonpb.AnyValue_DoubleValue:
+		return log.Float64Value(x.DoubleValue)
+	case *commonpb.AnyValue_StringValue:
+		return log.StringValue(x.StringValue)
+	case *commonpb.AnyValue_BytesValue:
+		return log.BytesValue(x.BytesValue)
+	case *commonpb.AnyValue_ArrayValue:
+		items := make([]log.Value, 0, len(x.ArrayValue.GetValues()))
+		for _, item := range x.ArrayValue.GetValues() {
+			items = append(items, logValue(item))
+		}
+		return log.SliceValue(items...)
+	case *commonpb.AnyValue_KvlistValue:
+		kvs := make([]log.KeyValue, 0, len(x.KvlistValue.GetValues()))
+		for _, kv := range x.KvlistValue.GetValues() {
+			kvs = append(kvs, log.KeyValue{Key: kv.GetKey(), Value: logValue(kv.GetValue())})
+		}
+		return log.MapValue(kvs...)
+	default:
+		return log.Value{}
+	}
+}
+
+// recordCapture is a log processor that keeps a copy of every record.
+type recordCapture struct {
+	records []sdklog.Record
+}
+
+func (c *recordCapture) OnEmit(_ context.Context, r *sdklog.Record) error {
+	c.records = append(c.records, r.Clone())
+	return nil
+}
+
+func (c *recordCapture) Enabled(context.Context, sdklog.EnabledParameters) bool { return true }
+func (c *recordCapture) Shutdown(context.Context) error                         { return nil }
+func (c *recordCapture) ForceFlush(context.Context) error                       { return nil }
+
+// --- OTLP to metrics ---
+
+// resourceMetricsData rebuilds SDK metric data from its OTLP form.
+// Histograms come back as float64, since OTLP does not record the
+// instrument's number type; exemplars are not kept.
+func resourceMetricsData(pm *metricspb.ResourceMetrics) (*metricdata.ResourceMetrics, error) {
+	rm := &metricdata.ResourceMetrics{Resource: resourceFromProto(pm.GetResource(), pm.GetSchemaUrl())}
+	for _, ps := range pm.GetScopeMetrics() {
+		sm := metricdata.ScopeMetrics{Scope: scopeFromProto(ps.GetScope(), ps.GetSchemaUrl())}
+		for _, m := range ps.GetMetrics() {
+			md, err := metricData(m)
+			if err != nil {
+				return nil, err
+			}
+			sm.Metrics = append(sm.Metrics, md)
+		}
+		rm.ScopeMetrics = append(rm.ScopeMetrics, sm)
+	}
+	return rm, nil
+}
+
+func metricData(m *metricspb.Metric) (metricdata.Metrics, error) {
+	out := metricdata.Metrics{Name: m.GetName(), Description: m.GetDescription(), Unit: m.GetUnit()}
+	switch d := m.GetData().(type) {
+	case *metricspb.Metric_Gauge:
+		if intPoints(d.Gauge.GetDataPoints()) {
+			out.Data = metricdata.Gauge[int64]{DataPoints: numberPointsData[int64](d.Gauge.GetDataPoints())}
+		} else {
+			out.Data = metricdata.Gauge[float64]{DataPoints: numberPointsData[float64](d.Gauge.GetDataPoints())}
+		}
+	case *metricspb.Metric_Sum:
+		temporality := temporalityData(d.Sum.GetAggregationTemporality())
+		if intPoints(d.Sum.GetDataPoints()) {
+			out.Data = metricdata.Sum[int64]{
+				DataPoints:  numberPointsData[int64](d.Sum.GetDataPoints()),
+				Temporality: temporality,
+				IsMonotonic: d.Sum.GetIsMonotonic(),
+			}
+		} else {
+			out.Data = metricdata.Sum[float64]{
+				DataPoints:  numberPointsData[float64](d.Sum.GetDataPoints()),
+				Temporality: temporality,
+				IsMonotonic: d.Sum.GetIsMonotonic(),
+			}
+		}
+	case *metricspb.Metric_Histogram:
+		out.Data = metricdata.Histogram[float64]{
+			DataPoints:  histogramPointsData(d.Histogram.GetDataPoints()),
+			Temporality: temporalityData(d.Histogram.GetAggregationTemporality()),
+		}
+	case *metricspb.Metric_ExponentialHistogram:
+		out.Data = metricdata.ExponentialHistogram[float64]{
+			DataPoints:  expHistogramPointsData(d.ExponentialHistogram.GetDataPoints()),
+			Temporality: temporalityData(d.ExponentialHistogram.GetAggregationTemporality()),
+		}
+	default:
+		return out, errors.New("export queue: unsupported aggregation for metric " + m.GetName())
+	}
+	return out, nil
+}
+
+// intPoints reports whether number points hold integers.
+func intPoints(points []*metricspb.NumberDataPoint) bool {
+	if len(points) == 0 {
+		return false
+	}
+	_, ok := points[0].GetValue().(*metricspb.NumberDataPoint_AsInt)
+	return ok
+}
+
+func temporalityData(t metricspb.AggregationTemporality) metricdata.Temporality {
+	switch t {
+	case metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA:
+		return metricdata.DeltaTemporality
+	case metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE:
+		return metricdata.CumulativeTemporality
+	}
+	return metricdata.Temporality(0)
+}
+
+func numberPointsData[N int64 | float64](points []*metricspb.NumberDataPoint) []metricdata.DataPoint[N] {
+	out := make([]metricdata.DataPoint[N], 0, len(points))
+	for _, p := range points {
+		var v N
+		switch pv := p.GetValue().(type) {
+		case *metricspb.NumberDataPoint_AsInt:
+			v = N(pv.AsInt)
+		case *metricspb.NumberDataPoint_AsDouble:
+			v = N(pv.AsDouble)
+		}
+		out = append(out, metricdata.DataPoint[N]{
+			Attributes: attribute.NewSet(attributeKVs(p.GetAttributes())...),
+			StartTime:  fromUnixNano(p.GetStartTimeUnixNano()),
+			Time:       fromUnixNano(p.GetTimeUnixNano()),
+			Value:      v,
+		})
+	}
+	return out
+}
+
+func histogramPointsData(points []*metricspb.HistogramDataPoint) []metricdata.HistogramDataPoint[float64] {
+	out := make([]metricdata.HistogramDataPoint[float64], 0, len(points))
+	for _, p := range points {
+		out = append(out, metricdata.HistogramDataPoint[float64]{
+			Attributes:   attribute.NewSet(attributeKVs(p.GetAttributes())...),
+			StartTime:    fromUnixNano(p.GetStartTimeUnixNano()),
+			Time:         fromUnixNano(p.GetTimeUnixNano()),
+			Count:        p.GetCount(),
+			Bounds:       p.GetExplicitBounds(),
+			BucketCounts: p.GetBucketCounts(),
+			Min:          extrema(p.Min),
+			Max:          extrema(p.Max),
+			Sum:          p.GetSum(),
+		})
+	}
+	return out
+}
+
+func expHistogramPointsData(points []*metricspb.ExponentialHistogramDataPoint) []metricdata.ExponentialHistogramDataPoint[float64] {
+	out := make([]metricdata.ExponentialHistogramDataPoint[float64], 0, len(points))
+	for _, p := range points {
+		out = append(out, metricdata.ExponentialHistogramDataPoint[float64]{
+			Attributes:     attribute.NewSet(attributeKVs(p.GetAttributes())...),
+			StartTime:      fromUnixNano(p.GetStartTimeUnixNano()),
+			Time:           fromUnixNano(p.GetTimeUnixNano()),
+			Count:          p.GetCount(),
+			Min:            extrema(p.Min),
+			Max:            extrema(p.Max),
+			Sum:            p.GetSum(),
+			Scale:          p.GetScale(),
+			ZeroCount:      p.GetZeroCount(),
+			ZeroThreshold:  p.GetZeroThreshold(),
+			PositiveBucket: metricdata.ExponentialBucket{Offset: p.GetPositive().GetOffset(), Counts: p.GetPositive().GetBucketCounts()},
+			NegativeBucket: metricdata.ExponentialBucket{Offset: p.GetNegative().GetOffset(), Counts: p.GetNegative().GetBucketCounts()},
+		})
+	}
+	return out
+}
+
+func extrema(v *float64) metricdata.Extrema[float64] {
+	if v == nil {
+		return metricdata.Extrema[float64]{}
+	}
+	return metricdata.NewExtrema(*v)
+}
+
+// --- Common ---
+
+// fromUnixNano is the inverse of unixNano.
+func fromUnixNano(n uint64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(n)) //nolint:gosec // Written by unixNano
+}
+
+func resourceFromProto(r *resourcepb.Resource, schemaURL string) *resource.Resource {
+	return resource.NewWithAttributes(schemaURL, attributeKVs(r.GetAttributes())...)
+}
+
+func scopeFromProto(s *commonpb.InstrumentationScope, schemaURL string) instrumentation.Scope {
+	return instrumentation.Scope{
+		Name:       s.GetName(),
+		Version:    s.GetVersion(),
+		SchemaURL:  schemaURL,
+		Attributes: attribute.NewSet(attributeKVs(s.GetAttributes())...),
+	}
+}
+
+// attributeKVs is the inverse of attributesProto.
+func attributeKVs(kvs []*commonpb.KeyValue) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		out = append(out, attribute.KeyValue{Key: attribute.Key(kv.GetKey()), Value: attributeValue(kv.GetValue())})
+	}
+	return out
+}
+
+func attributeValue(v *commonpb.AnyValue) attribute.Value {
+	switch x := v.GetValue().(type) {
+	case *commonpb.AnyValue_BoolValue:
+		return attribute.BoolValue(x.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return attribute.Int64Value(x.IntValue)
+	case *commonpb.AnyValue_DoubleValue:
+		return attribute.Float64Value(x.DoubleValue)
+	case *commonpb.AnyValue_ArrayValue:
+		return arrayAttributeValue(x.ArrayValue.GetValues())
+	default:
+		return attribute.StringValue(v.GetStringValue())
+	}
+}
+
+// arrayAttributeValue rebuilds a homogeneous slice attribute; the element
+// type is taken from the first element.
+func arrayAttributeValue(vals []*commonpb.AnyValue) attribute.Value {
+	if len(vals) == 0 {
+		return attribute.StringSliceValue(nil)
+	}
+	switch vals[0].GetValue().(type) {
+	case *commonpb.AnyValue_BoolValue:
+		out := make([]bool, len(vals))
+		for i, v := range vals {
+			out[i] = v.GetBoolValue()
+		}
+		return attribute.BoolSliceValue(out)
+	case *commonpb.AnyValue_IntValue:
+		out := make([]int64, len(vals))
+		for i, v := range vals {
+			out[i] = v.GetIntValue()
+		}
+		return attribute.Int64SliceValue(out)
+	case *commonpb.AnyValue_DoubleValue:
+		out := make([]float64, len(vals))
+		for i, v := range vals {
+			out[i] = v.GetDoubleValue()
+		}
+		return attribute.Float64SliceValue(out)
+	default:
+		out := make([]string, len(vals))
+		for i, v := range vals {
+			out[i] = v.GetStringValue()
+		}
+		return attribute.StringSliceValue(out)
+	}
+}
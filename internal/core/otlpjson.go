@@ -0,0 +1,481 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+// otlpJSONWriter writes one OTLP-JSON document per line: each export call
+// becomes a TracesData or MetricsData line, the format read by the
+// collector's otlpjsonfile receiver.
+type otlpJSONWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// newOTLPJSONWriter returns the writer for protocol: stdout, or a rotating
+// file built from file.
+func newOTLPJSONWriter(protocol string, file config.FileConfig) *otlpJSONWriter {
+	if protocol == "file" {
+		return &otlpJSONWriter{w: config.NewFileWriter(file)}
+	}
+	return &otlpJSONWriter{w: os.Stdout}
+}
+
+func (w *otlpJSONWriter) write(m proto.Message) error {
+	line, err := marshalOTLPJSON(m)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.w.Write(line)
+	return err
+}
+
+// close closes the underlying file. Stdout is left open.
+func (w *otlpJSONWriter) close() error {
+	if c, ok := w.w.(io.Closer); ok && w.w != os.Stdout {
+		return c.Close()
+	}
+	return nil
+}
+
+// idKeys are the OTLP-JSON fields that hold trace and span IDs.
+var idKeys = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+// marshalOTLPJSON encodes m as one line of OTLP-JSON. OTLP-JSON differs from
+// the canonical protobuf JSON mapping in two ways: enums are integers and
+// trace/span IDs are hex rather than base64.
+func marshalOTLPJSON(m proto.Message) ([]byte, error) {
+	raw, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	hexIDs(doc)
+	line, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
+
+// hexIDs rewrites base64 trace and span IDs in a decoded document to hex.
+func hexIDs(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if s, ok := child.(string); ok && idKeys[k] {
+				if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+					v[k] = hex.EncodeToString(b)
+				}
+				continue
+			}
+			hexIDs(child)
+		}
+	case []any:
+		for _, child := range v {
+			hexIDs(child)
+		}
+	}
+}
+
+// --- Traces ---
+
+// otlpJSONSpanExporter writes spans as OTLP-JSON lines.
+type otlpJSONSpanExporter struct {
+	w *otlpJSONWriter
+}
+
+// newOTLPJSONSpanExporter returns a span exporter for the stdout and file protocols.
+func newOTLPJSONSpanExporter(cfg config.TracingConfig) sdktrace.SpanExporter {
+	return &otlpJSONSpanExporter{w: newOTLPJSONWriter(cfg.Protocol, cfg.File)}
+}
+
+func (e *otlpJSONSpanExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	return e.w.write(&tracepb.TracesData{ResourceSpans: resourceSpans(spans)})
+}
+
+func (e *otlpJSONSpanExporter) Shutdown(context.Context) error {
+	return e.w.close()
+}
+
+// resourceSpans groups spans by resource and instrumentation scope, keeping
+// the order in which each group is first seen.
+func resourceSpans(spans []sdktrace.ReadOnlySpan) []*tracepb.ResourceSpans {
+	type scopeKey struct {
+		res   attribute.Distinct
+		scope instrumentation.Scope
+	}
+	var out []*tracepb.ResourceSpans
+	byRes := make(map[attribute.Distinct]*tracepb.ResourceSpans)
+	byScope := make(map[scopeKey]*tracepb.ScopeSpans)
+
+	for _, s := range spans {
+		res := s.Resource()
+		resKey := res.Equivalent()
+		rs, ok := byRes[resKey]
+		if !ok {
+			rs = &tracepb.ResourceSpans{Resource: resourceProto(res), SchemaUrl: res.SchemaURL()}
+			byRes[resKey] = rs
+			out = append(out, rs)
+		}
+		key := scopeKey{res: resKey, scope: s.InstrumentationScope()}
+		ss, ok := byScope[key]
+		if !ok {
+			ss = &tracepb.ScopeSpans{Scope: scopeProto(key.scope), SchemaUrl: key.scope.SchemaURL}
+			byScope[key] = ss
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		}
+		ss.Spans = append(ss.Spans, spanProto(s))
+	}
+	return out
+}
+
+func spanProto(s sdktrace.ReadOnlySpan) *tracepb.Span {
+	sc := s.SpanContext()
+	tid, sid := sc.TraceID(), sc.SpanID()
+	span := &tracepb.Span{
+		TraceId:                tid[:],
+		SpanId:                 sid[:],
+		TraceState:             sc.TraceState().String(),
+		Flags:                  uint32(sc.TraceFlags()),
+		Name:                   s.Name(),
+		Kind:                   tracepb.Span_SpanKind(s.SpanKind()),
+		StartTimeUnixNano:      unixNano(s.StartTime()),
+		EndTimeUnixNano:        unixNano(s.EndTime()),
+		Attributes:             attributesProto(s.Attributes()),
+		DroppedAttributesCount: count32(s.DroppedAttributes()),
+		DroppedEventsCount:     count32(s.DroppedEvents()),
+		DroppedLinksCount:      count32(s.DroppedLinks()),
+		Status:                 statusProto(s.Status()),
+	}
+	if p := s.Parent(); p.SpanID().IsValid() {
+		psid := p.SpanID()
+		span.ParentSpanId = psid[:]
+	}
+	for _, ev := range s.Events() {
+		span.Events = append(span.Events, &tracepb.Span_Event{
+			TimeUnixNano:           unixNano(ev.Time),
+			Name:                   ev.Name,
+			Attributes:             attributesProto(ev.Attributes),
+			DroppedAttributesCount: count32(ev.DroppedAttributeCount),
+		})
+	}
+	for _, l := range s.Links() {
+		ltid, lsid := l.SpanContext.TraceID(), l.SpanContext.SpanID()
+		span.Links = append(span.Links, &tracepb.Span_Link{
+			TraceId:                ltid[:],
+			SpanId:                 lsid[:],
+			TraceState:             l.SpanContext.TraceState().String(),
+			Attributes:             attributesProto(l.Attributes),
+			DroppedAttributesCount: count32(l.DroppedAttributeCount),
+			Flags:                  uint32(l.SpanContext.TraceFlags()),
+		})
+	}
+	return span
+}
+
+func statusProto(s sdktrace.Status) *tracepb.Status {
+	code := tracepb.Status_STATUS_CODE_UNSET
+	switch s.Code {
+	case codes.Ok:
+		code = tracepb.Status_STATUS_CODE_OK
+	case codes.Error:
+		code = tracepb.Status_STATUS_CODE_ERROR
+	}
+	return &tracepb.Status{Code: code, Message: s.Description}
+}
+
+// --- Metrics ---
+
+// otlpJSONMetricExporter writes metrics as OTLP-JSON lines.
+type otlpJSONMetricExporter struct {
+	w           *otlpJSONWriter
+	temporality sdkmetric.TemporalitySelector
+}
+
+// newOTLPJSONMetricExporter returns a metric exporter for the stdout and file protocols.
+func newOTLPJSONMetricExporter(cfg config.MetricsConfig) sdkmetric.Exporter {
+	return &otlpJSONMetricExporter{
+		w:           newOTLPJSONWriter(cfg.Protocol, cfg.File),
+		temporality: temporalitySelector(cfg.Temporality),
+	}
+}
+
+func (e *otlpJSONMetricExporter) Temporality(k sdkmetric.InstrumentKind) metricdata.Temporality {
+	return e.temporality(k)
+}
+
+func (e *otlpJSONMetricExporter) Aggregation(k sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(k)
+}
+
+func (e *otlpJSONMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	out, err := resourceMetricsProto(rm)
+	if len(out.ScopeMetrics) > 0 {
+		err = errors.Join(err, e.w.write(&metricspb.MetricsData{ResourceMetrics: []*metricspb.ResourceMetrics{out}}))
+	}
+	return err
+}
+
+func (e *otlpJSONMetricExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *otlpJSONMetricExporter) Shutdown(context.Context) error {
+	return e.w.close()
+}
+
+// resourceMetricsProto converts rm to its OTLP form. Metrics with an
+// unsupported aggregation are left out and reported in the error.
+func resourceMetricsProto(rm *metricdata.ResourceMetrics) (*metricspb.ResourceMetrics, error) {
+	out := &metricspb.ResourceMetrics{Resource: resourceProto(rm.Resource), SchemaUrl: rm.Resource.SchemaURL()}
+	var errs []error
+	for _, sm := range rm.ScopeMetrics {
+		scope := &metricspb.ScopeMetrics{Scope: scopeProto(sm.Scope), SchemaUrl: sm.Scope.SchemaURL}
+		for _, m := range sm.Metrics {
+			pm, err := metricProto(m)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			scope.Metrics = append(scope.Metrics, pm)
+		}
+		if len(scope.Metrics) > 0 {
+			out.ScopeMetrics = append(out.ScopeMetrics, scope)
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+func metricProto(m metricdata.Metrics) (*metricspb.Metric, error) {
+	pm := &metricspb.Metric{Name: m.Name, Description: m.Description, Unit: m.Unit}
+	switch d := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		pm.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: numberPoints(d.DataPoints)}}
+	case metricdata.Gauge[float64]:
+		pm.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: numberPoints(d.DataPoints)}}
+	case metricdata.Sum[int64]:
+		pm.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			DataPoints:             numberPoints(d.DataPoints),
+			AggregationTemporality: temporalityProto(d.Temporality),
+			IsMonotonic:            d.IsMonotonic,
+		}}
+	case metricdata.Sum[float64]:
+		pm.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			DataPoints:             numberPoints(d.DataPoints),
+			AggregationTemporality: temporalityProto(d.Temporality),
+			IsMonotonic:            d.IsMonotonic,
+		}}
+	case metricdata.Histogram[int64]:
+		pm.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			DataPoints:             histogramPoints(d.DataPoints),
+			AggregationTemporality: temporalityProto(d.Temporality),
+		}}
+	case metricdata.Histogram[float64]:
+		pm.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			DataPoints:             histogramPoints(d.DataPoints),
+			AggregationTemporality: temporalityProto(d.Temporality),
+		}}
+	case metricdata.ExponentialHistogram[int64]:
+		pm.Data = &metricspb.Metric_ExponentialHistogram{ExponentialHistogram: &metricspb.ExponentialHistogram{
+			DataPoints:             expHistogramPoints(d.DataPoints),
+			AggregationTemporality: temporalityProto(d.Temporality),
+		}}
+	case metricdata.ExponentialHistogram[float64]:
+		pm.Data = &metricspb.Metric_ExponentialHistogram{ExponentialHistogram: &metricspb.ExponentialHistogram{
+			DataPoints:             expHistogramPoints(d.DataPoints),
+			AggregationTemporality: temporalityProto(d.Temporality),
+		}}
+	default:
+		return nil, errors.New("otlp json: unsupported aggregation for metric " + m.Name)
+	}
+	return pm, nil
+}
+
+func temporalityProto(t metricdata.Temporality) metricspb.AggregationTemporality {
+	switch t {
+	case metricdata.DeltaTemporality:
+		return metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+	case metricdata.CumulativeTemporality:
+		return metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+	}
+	return metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED
+}
+
+func numberPoints[N int64 | float64](points []metricdata.DataPoint[N]) []*metricspb.NumberDataPoint {
+	out := make([]*metricspb.NumberDataPoint, 0, len(points))
+	for _, p := range points {
+		dp := &metricspb.NumberDataPoint{
+			Attributes:        attributesProto(p.Attributes.ToSlice()),
+			StartTimeUnixNano: unixNano(p.StartTime),
+			TimeUnixNano:      unixNano(p.Time),
+		}
+		switch v := any(p.Value).(type) {
+		case int64:
+			dp.Value = &metricspb.NumberDataPoint_AsInt{AsInt: v}
+		case float64:
+			dp.Value = &metricspb.NumberDataPoint_AsDouble{AsDouble: v}
+		}
+		out = append(out, dp)
+	}
+	return out
+}
+
+func histogramPoints[N int64 | float64](points []metricdata.HistogramDataPoint[N]) []*metricspb.HistogramDataPoint {
+	out := make([]*metricspb.HistogramDataPoint, 0, len(points))
+	for _, p := range points {
+		sum := float64(p.Sum)
+		dp := &metricspb.HistogramDataPoint{
+			Attributes:        attributesProto(p.Attributes.ToSlice()),
+			StartTimeUnixNano: unixNano(p.StartTime),
+			TimeUnixNano:      unixNano(p.Time),
+			Count:             p.Count,
+			Sum:               &sum,
+			BucketCounts:      p.BucketCounts,
+			ExplicitBounds:    p.Bounds,
+		}
+		if v, ok := p.Min.Value(); ok {
+			f := float64(v)
+			dp.Min = &f
+		}
+		if v, ok := p.Max.Value(); ok {
+			f := float64(v)
+			dp.Max = &f
+		}
+		out = append(out, dp)
+	}
+	return out
+}
+
+func expHistogramPoints[N int64 | float64](points []metricdata.ExponentialHistogramDataPoint[N]) []*metricspb.ExponentialHistogramDataPoint {
+	out := make([]*metricspb.ExponentialHistogramDataPoint, 0, len(points))
+	for _, p := range points {
+		sum := float64(p.Sum)
+		dp := &metricspb.ExponentialHistogramDataPoint{
+			Attributes:        attributesProto(p.Attributes.ToSlice()),
+			StartTimeUnixNano: unixNano(p.StartTime),
+			TimeUnixNano:      unixNano(p.Time),
+			Count:             p.Count,
+			Sum:               &sum,
+			Scale:             p.Scale,
+			ZeroCount:         p.ZeroCount,
+			ZeroThreshold:     p.ZeroThreshold,
+			Positive:          &metricspb.ExponentialHistogramDataPoint_Buckets{Offset: p.PositiveBucket.Offset, BucketCounts: p.PositiveBucket.Counts},
+			Negative:          &metricspb.ExponentialHistogramDataPoint_Buckets{Offset: p.NegativeBucket.Offset, BucketCounts: p.NegativeBucket.Counts},
+		}
+		if v, ok := p.Min.Value(); ok {
+			f := float64(v)
+			dp.Min = &f
+		}
+		if v, ok := p.Max.Value(); ok {
+			f := float64(v)
+			dp.Max = &f
+		}
+		out = append(out, dp)
+	}
+	return out
+}
+
+// --- Common ---
+
+// unixNano returns t in nanoseconds since the Unix epoch, or 0 for the zero time.
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano()) //nolint:gosec // OTEL timestamps are after 1970
+}
+
+// count32 converts a dropped-item count to its OTLP field type.
+func count32(n int) uint32 {
+	return uint32(min(max(n, 0), math.MaxUint32)) //nolint:gosec // Clamped above
+}
+
+func resourceProto(r *resource.Resource) *resourcepb.Resource {
+	if r == nil {
+		return &resourcepb.Resource{}
+	}
+	return &resourcepb.Resource{Attributes: attributesProto(r.Attributes())}
+}
+
+func scopeProto(s instrumentation.Scope) *commonpb.InstrumentationScope {
+	return &commonpb.InstrumentationScope{
+		Name:       s.Name,
+		Version:    s.Version,
+		Attributes: attributesProto(s.Attributes.ToSlice()),
+	}
+}
+
+func attributesProto(attrs []attribute.KeyValue) []*commonpb.KeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make([]*commonpb.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		out = append(out, &commonpb.KeyValue{Key: string(kv.Key), Value: anyValueProto(kv.Value)})
+	}
+	return out
+}
+
+func anyValueProto(v attribute.Value) *commonpb.AnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case attribute.INT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case attribute.FLOAT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case attribute.BOOLSLICE:
+		return arrayValueProto(v.AsBoolSlice(), attribute.BoolValue)
+	case attribute.INT64SLICE:
+		return arrayValueProto(v.AsInt64Slice(), attribute.Int64Value)
+	case attribute.FLOAT64SLICE:
+		return arrayValueProto(v.AsFloat64Slice(), attribute.Float64Value)
+	case attribute.STRINGSLICE:
+		return arrayValueProto(v.AsStringSlice(), attribute.StringValue)
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Emit()}}
+	}
+}
+
+func arrayValueProto[T any](vals []T, conv func(T) attribute.Value) *commonpb.AnyValue {
+	arr := &commonpb.ArrayValue{Values: make([]*commonpb.AnyValue, 0, len(vals))}
+	for _, v := range vals {
+		arr.Values = append(arr.Values, anyValueProto(conv(v)))
+	}
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: arr}}
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+// otlpJSONLine is the subset of an OTLP-JSON line checked by the tests.
+type otlpJSONLine struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID      string `json:"traceId"`
+				SpanID       string `json:"spanId"`
+				ParentSpanID string `json:"parentSpanId"`
+				Name         string `json:"name"`
+				Kind         int    `json:"kind"`
+				Status       struct {
+					Code int `json:"code"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+	ResourceMetrics []struct {
+		ScopeMetrics []struct {
+			Metrics []struct {
+				Name string `json:"name"`
+				Sum  struct {
+					AggregationTemporality int `json:"aggregationTemporality"`
+					DataPoints             []struct {
+						AsInt string `json:"asInt"`
+					} `json:"dataPoints"`
+				} `json:"sum"`
+			} `json:"metrics"`
+		} `json:"scopeMetrics"`
+	} `json:"resourceMetrics"`
+}
+
+func TestOTLPJSONSpanExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exp := &otlpJSONSpanExporter{w: &otlpJSONWriter{w: &buf}}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, child := tp.Tracer("test").Start(ctx, "child")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("wrote %d lines, want 2 (one per export)", len(lines))
+	}
+	var got otlpJSONLine
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("line is not JSON: %v", err)
+	}
+	span := got.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if span.Name != "child" {
+		t.Errorf("name = %q, want child", span.Name)
+	}
+	if want := child.SpanContext().TraceID().String(); span.TraceID != want {
+		t.Errorf("traceId = %q, want hex %q", span.TraceID, want)
+	}
+	if want := parent.SpanContext().SpanID().String(); span.ParentSpanID != want {
+		t.Errorf("parentSpanId = %q, want hex %q", span.ParentSpanID, want)
+	}
+	if span.Kind != 1 || span.Status.Code != 2 {
+		t.Errorf("kind, status = %d, %d, want 1 (internal), 2 (error)", span.Kind, span.Status.Code)
+	}
+}
+
+func TestOTLPJSONMetricExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exp := &otlpJSONMetricExporter{w: &otlpJSONWriter{w: &buf}, temporality: temporalitySelector("delta")}
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)))
+
+	counter, _ := mp.Meter("test").Int64Counter("blocks.applied")
+	counter.Add(context.Background(), 5, metric.WithAttributes(attribute.String("shard", "3")))
+	if err := mp.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() error: %v", err)
+	}
+
+	var got otlpJSONLine
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("line is not JSON: %v", err)
+	}
+	m := got.ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+	if m.Name != "blocks.applied" || len(m.Sum.DataPoints) != 1 || m.Sum.DataPoints[0].AsInt != "5" {
+		t.Errorf("metric = %+v, want blocks.applied sum of 5", m)
+	}
+	if m.Sum.AggregationTemporality != 1 {
+		t.Errorf("aggregationTemporality = %d, want 1 (delta)", m.Sum.AggregationTemporality)
+	}
+}
+
+func TestOTLPJSONSpanExporter_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	exp := newOTLPJSONSpanExporter(config.TracingConfig{Protocol: "file", File: config.FileConfig{Path: path, MaxSizeMB: 1}})
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "op")
+	span.End()
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	if !bytes.Contains(data, []byte(`"name":"op"`)) || !bytes.HasSuffix(data, []byte("\n")) {
+		t.Errorf("file = %s, want one OTLP-JSON line for op", data)
+	}
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewPropagator(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		wantHdr []string // headers that must be injected
+		wantErr bool
+	}{
+		{"default", nil, []string{"traceparent"}, false},
+		{"b3 single", []string{"b3"}, []string{"b3"}, false},
+		{"b3 multi", []string{"b3multi"}, []string{"x-b3-traceid", "x-b3-spanid"}, false},
+		{"jaeger", []string{"jaeger"}, []string{"uber-trace-id"}, false},
+		{"mixed case", []string{"TraceContext", "B3"}, []string{"traceparent", "b3"}, false},
+		{"none", []string{"none"}, nil, false},
+		{"unknown", []string{"xray"}, nil, true},
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPropagator(tt.names)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewPropagator() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewPropagator() error: %v", err)
+			}
+
+			carrier := propagation.MapCarrier{}
+			p.Inject(ctx, carrier)
+
+			keys := carrier.Keys()
+			sort.Strings(keys)
+			if len(tt.wantHdr) == 0 && len(keys) != 0 {
+				t.Errorf("injected headers %v, want none", keys)
+			}
+			for _, h := range tt.wantHdr {
+				if carrier.Get(h) == "" {
+					t.Errorf("header %q not injected (got %s)", h, strings.Join(keys, ","))
+				}
+			}
+
+			// Round-trip: whatever was injected must extract to the same trace.
+			if len(tt.wantHdr) > 0 {
+				got := trace.SpanContextFromContext(p.Extract(context.Background(), carrier))
+				if got.TraceID() != sc.TraceID() {
+					t.Errorf("extracted trace ID %s, want %s", got.TraceID(), sc.TraceID())
+				}
+			}
+		})
+	}
+}
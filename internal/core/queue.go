@@ -0,0 +1,602 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+// QueueStats reports an export queue's counters. Items are spans, log
+// records, or metrics, depending on the signal.
+type QueueStats struct {
+	Queued       uint64 `json:"queued"`        // items written to disk
+	Replayed     uint64 `json:"replayed"`      // items sent from disk after the endpoint recovered
+	Dropped      uint64 `json:"dropped"`       // items discarded by MaxSizeMB, MaxAge, unreadable files, or permanent rejections
+	Pending      int    `json:"pending"`       // items currently on disk
+	PendingBytes int64  `json:"pending_bytes"` // size of the stored batches
+}
+
+// ExportQueue is a disk-backed FIFO of encoded export batches, one file per
+// batch. Batches are queued when a direct export fails, or when earlier
+// batches are still stored, and replayed oldest first by a background loop.
+//
+// Files are named by sequence number and start with a header holding the
+// batch's creation time and item count. They are written under a temporary
+// name and renamed, so a crash never leaves a partial batch to replay.
+type ExportQueue struct {
+	dir      string
+	maxBytes int64
+	maxAge   time.Duration
+	send     func(context.Context, []byte) error // replays one stored batch
+
+	mu      sync.Mutex
+	files   []queueFile // oldest first
+	size    int64
+	nextSeq uint64
+
+	queued   atomic.Uint64
+	replayed atomic.Uint64
+	dropped  atomic.Uint64
+
+	stopOnce sync.Once
+	stop     context.CancelFunc
+	done     chan struct{}
+}
+
+type queueFile struct {
+	name    string
+	created time.Time
+	items   int
+	size    int64
+}
+
+const (
+	queueFileExt    = ".batch"
+	queueHeaderSize = 12 // creation time (unix nanoseconds) and item count
+)
+
+// NewExportQueue opens the queue for signal under cfg.Dir, picking up batches
+// left by a previous run, and starts the replay loop. send delivers one
+// stored batch; it is called from the loop only, so batches leave in order.
+func NewExportQueue(cfg config.QueueConfig, signal string, send func(context.Context, []byte) error) (*ExportQueue, error) {
+	maxSizeMB := cfg.MaxSizeMB
+	if maxSizeMB <= 0 {
+		maxSizeMB = 100
+	}
+	maxAge := cfg.MaxAge
+	if maxAge <= 0 {
+		maxAge = 24 * time.Hour
+	}
+	retry := cfg.RetryInterval
+	if retry <= 0 {
+		retry = 10 * time.Second
+	}
+
+	q := &ExportQueue{
+		dir:      filepath.Join(cfg.Dir, signal),
+		maxBytes: int64(maxSizeMB) << 20,
+		maxAge:   maxAge,
+		send:     send,
+		done:     make(chan struct{}),
+	}
+	if err := os.MkdirAll(q.dir, 0o750); err != nil {
+		return nil, fmt.Errorf("export queue: %w", err)
+	}
+	if err := q.load(); err != nil {
+		return nil, fmt.Errorf("export queue: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q.stop = cancel
+	go q.loop(ctx, retry)
+	return q, nil
+}
+
+// load indexes the batches already in the directory and removes
+// temporary files from interrupted writes.
+func (q *ExportQueue) load() error {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries { // sorted by name, so oldest first
+		path := filepath.Join(q.dir, e.Name())
+		if e.IsDir() {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(e.Name(), queueFileExt), 10, 64)
+		if !strings.HasSuffix(e.Name(), queueFileExt) || err != nil {
+			_ = os.Remove(path)
+			continue
+		}
+		f, err := readQueueHeader(path)
+		if err != nil {
+			_ = os.Remove(path)
+			continue
+		}
+		f.name = e.Name()
+		q.files = append(q.files, f)
+		q.size += f.size
+		q.nextSeq = seq + 1
+	}
+	return nil
+}
+
+func readQueueHeader(path string) (queueFile, error) {
+	file, err := os.Open(path) //nolint:gosec // Path is inside the configured queue directory
+	if err != nil {
+		return queueFile{}, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var hdr [queueHeaderSize]byte
+	if _, err := io.ReadFull(file, hdr[:]); err != nil {
+		return queueFile{}, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return queueFile{}, err
+	}
+	return queueFile{
+		created: time.Unix(0, int64(binary.BigEndian.Uint64(hdr[:8]))), //nolint:gosec // Written from UnixNano
+		items:   int(binary.BigEndian.Uint32(hdr[8:])),
+		size:    info.Size(),
+	}, nil
+}
+
+// export sends a batch of items directly when nothing is queued, and queues
+// it (encoded on demand) when something is or the send fails with a
+// retryable error. It returns nil once the batch is sent or stored.
+func (q *ExportQueue) export(ctx context.Context, items int, direct func(context.Context) error, encode func() ([]byte, error)) error {
+	if items == 0 {
+		return nil
+	}
+	var sendErr error
+	if q.empty() {
+		if sendErr = direct(ctx); sendErr == nil {
+			return nil
+		}
+		if !retryable(sendErr) {
+			q.dropped.Add(uint64(items)) //nolint:gosec // items is positive
+			return sendErr
+		}
+	}
+	payload, err := encode()
+	if err == nil {
+		err = q.push(payload, items)
+	}
+	if err != nil {
+		q.dropped.Add(uint64(items)) //nolint:gosec // items is positive
+		return errors.Join(sendErr, err)
+	}
+	return nil
+}
+
+func (q *ExportQueue) empty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.files) == 0
+}
+
+// push stores one encoded batch, dropping the oldest batches to stay
+// within the size cap.
+func (q *ExportQueue) push(payload []byte, items int) error {
+	size := int64(queueHeaderSize + len(payload))
+	if size > q.maxBytes {
+		return fmt.Errorf("export queue: batch of %d bytes exceeds the %d byte cap", size, q.maxBytes)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.expireLocked(time.Now())
+	for len(q.files) > 0 && q.size+size > q.maxBytes {
+		q.dropLocked(q.files[0])
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%020d%s", q.nextSeq, queueFileExt)
+	data := make([]byte, queueHeaderSize, size)
+	binary.BigEndian.PutUint64(data[:8], uint64(now.UnixNano())) //nolint:gosec // Current time is after 1970
+	binary.BigEndian.PutUint32(data[8:], uint32(items))          //nolint:gosec // Batch sizes fit in 32 bits
+	data = append(data, payload...)
+	if err := writeFileSync(filepath.Join(q.dir, name), data); err != nil {
+		return fmt.Errorf("export queue: %w", err)
+	}
+
+	q.nextSeq++
+	q.files = append(q.files, queueFile{name: name, created: now, items: items, size: size})
+	q.size += size
+	q.queued.Add(uint64(items)) //nolint:gosec // items is positive
+	return nil
+}
+
+// writeFileSync writes data to a temporary file, syncs it, and renames it
+// to path.
+func writeFileSync(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600) //nolint:gosec // Path is inside the configured queue directory
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (q *ExportQueue) loop(ctx context.Context, interval time.Duration) {
+	defer close(q.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		q.drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain replays stored batches oldest first and stops at the first
+// retryable failure; the failed batch is retried on the next tick. Batches
+// that cannot be decoded or are rejected permanently are dropped, so they do
+// not hold up the queue until MaxAge.
+func (q *ExportQueue) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		q.mu.Lock()
+		q.expireLocked(time.Now())
+		if len(q.files) == 0 {
+			q.mu.Unlock()
+			return
+		}
+		f := q.files[0]
+		q.mu.Unlock()
+
+		data, err := os.ReadFile(filepath.Join(q.dir, f.name))
+		if err != nil || len(data) < queueHeaderSize {
+			q.mu.Lock()
+			q.dropLocked(f)
+			q.mu.Unlock()
+			otel.Handle(fmt.Errorf("export queue: unreadable batch %s dropped: %w", f.name, err))
+			continue
+		}
+		if err := q.send(ctx, data[queueHeaderSize:]); err != nil {
+			if retryable(err) {
+				return
+			}
+			q.mu.Lock()
+			q.dropLocked(f)
+			q.mu.Unlock()
+			otel.Handle(fmt.Errorf("export queue: rejected batch %s dropped: %w", f.name, err))
+			continue
+		}
+
+		q.mu.Lock()
+		if q.removeLocked(f) {
+			q.replayed.Add(uint64(f.items)) //nolint:gosec // Item counts are positive
+		}
+		q.mu.Unlock()
+	}
+}
+
+// permanentError marks a send failure that retrying cannot fix, such as a
+// stored batch that no longer decodes.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// httpRejection matches the error the OTLP HTTP exporters return for a
+// non-retryable response ("failed to send logs to URL: 400 Bad Request
+// (...)"). Retryable statuses (429, 502, 503, 504) are reported differently.
+var httpRejection = regexp.MustCompile(`failed to send (?:[a-z]+ )?to \S+: [45]\d\d `)
+
+// retryable reports whether a failed send may succeed later. gRPC errors
+// follow the OTLP retry rules by status code; HTTP rejections and
+// permanentErrors are final; anything else (e.g. a refused connection) is
+// assumed transient.
+func retryable(err error) bool {
+	var perm *permanentError
+	if errors.As(err, &perm) {
+		return false
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted,
+			codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+			return true
+		default:
+			return false
+		}
+	}
+	return !httpRejection.MatchString(err.Error())
+}
+
+// expireLocked drops batches older than maxAge.
+func (q *ExportQueue) expireLocked(now time.Time) {
+	for len(q.files) > 0 && now.Sub(q.files[0].created) > q.maxAge {
+		q.dropLocked(q.files[0])
+	}
+}
+
+// dropLocked removes f and counts its items as dropped.
+func (q *ExportQueue) dropLocked(f queueFile) {
+	if q.removeLocked(f) {
+		q.dropped.Add(uint64(f.items)) //nolint:gosec // Item counts are positive
+	}
+}
+
+// removeLocked deletes f, reporting false if it was already gone (e.g.
+// dropped by push while being replayed).
+func (q *ExportQueue) removeLocked(f queueFile) bool {
+	for i, qf := range q.files {
+		if qf.name != f.name {
+			continue
+		}
+		q.files = append(q.files[:i], q.files[i+1:]...)
+		q.size -= qf.size
+		if err := os.Remove(filepath.Join(q.dir, f.name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			otel.Handle(fmt.Errorf("export queue: %w", err))
+		}
+		return true
+	}
+	return false
+}
+
+// Close stops the replay loop. Stored batches stay on disk for the next run.
+func (q *ExportQueue) Close() {
+	if q == nil {
+		return
+	}
+	q.stopOnce.Do(q.stop)
+	<-q.done
+}
+
+// Stats returns the current counters. Safe on a nil *ExportQueue.
+func (q *ExportQueue) Stats() QueueStats {
+	if q == nil {
+		return QueueStats{}
+	}
+	q.mu.Lock()
+	pending := 0
+	for _, f := range q.files {
+		pending += f.items
+	}
+	size := q.size
+	q.mu.Unlock()
+	return QueueStats{
+		Queued:       q.queued.Load(),
+		Replayed:     q.replayed.Load(),
+		Dropped:      q.dropped.Load(),
+		Pending:      pending,
+		PendingBytes: size,
+	}
+}
+
+// RegisterQueueMetrics reports the counters of the given queues, keyed by
+// signal name, as observable instruments on meter. Nil queues are skipped;
+// nothing is registered when all are nil.
+func RegisterQueueMetrics(meter metric.Meter, queues map[string]*ExportQueue) error {
+	for signal, q := range queues {
+		if q == nil {
+			delete(queues, signal)
+		}
+	}
+	if len(queues) == 0 {
+		return nil
+	}
+
+	queued, err := meter.Int64ObservableCounter("ion.export_queue.queued",
+		metric.WithUnit("{item}"), metric.WithDescription("Items written to the export queue after a failed or deferred export."))
+	if err != nil {
+		return err
+	}
+	replayed, err := meter.Int64ObservableCounter("ion.export_queue.replayed",
+		metric.WithUnit("{item}"), metric.WithDescription("Items sent from the export queue after the endpoint recovered."))
+	if err != nil {
+		return err
+	}
+	dropped, err := meter.Int64ObservableCounter("ion.export_queue.dropped",
+		metric.WithUnit("{item}"), metric.WithDescription("Items discarded by the export queue: size cap, retention age, or permanent rejection."))
+	if err != nil {
+		return err
+	}
+	pending, err := meter.Int64ObservableUpDownCounter("ion.export_queue.pending",
+		metric.WithUnit("{item}"), metric.WithDescription("Items currently stored in the export queue."))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for signal, q := range queues {
+			s := q.Stats()
+			attrs := metric.WithAttributes(attribute.String("signal", signal))
+			o.ObserveInt64(queued, int64(s.Queued), attrs)     //nolint:gosec // Counters stay far below MaxInt64
+			o.ObserveInt64(replayed, int64(s.Replayed), attrs) //nolint:gosec // Counters stay far below MaxInt64
+			o.ObserveInt64(dropped, int64(s.Dropped), attrs)   //nolint:gosec // Counters stay far below MaxInt64
+			o.ObserveInt64(pending, int64(s.Pending), attrs)
+		}
+		return nil
+	}, queued, replayed, dropped, pending)
+	return err
+}
+
+// --- Signal adapters ---
+
+// queuedTraceClient queues span uploads that fail. It wraps the OTLP
+// client rather than the exporter, so batches are stored as the protobuf
+// the client already sends.
+type queuedTraceClient struct {
+	otlptrace.Client
+	queue *ExportQueue
+}
+
+// newQueuedTraceClient wraps client with a queue under cfg.Dir.
+func newQueuedTraceClient(client otlptrace.Client, cfg config.QueueConfig) (*queuedTraceClient, error) {
+	c := &queuedTraceClient{Client: client}
+	q, err := NewExportQueue(cfg, "traces", func(ctx context.Context, payload []byte) error {
+		var td tracepb.TracesData
+		if err := proto.Unmarshal(payload, &td); err != nil {
+			return &permanentError{err: err}
+		}
+		return c.Client.UploadTraces(ctx, td.ResourceSpans)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.queue = q
+	return c, nil
+}
+
+func (c *queuedTraceClient) UploadTraces(ctx context.Context, spans []*tracepb.ResourceSpans) error {
+	items := 0
+	for _, rs := range spans {
+		for _, ss := range rs.ScopeSpans {
+			items += len(ss.Spans)
+		}
+	}
+	return c.queue.export(ctx, items,
+		func(ctx context.Context) error { return c.Client.UploadTraces(ctx, spans) },
+		func() ([]byte, error) { return proto.Marshal(&tracepb.TracesData{ResourceSpans: spans}) },
+	)
+}
+
+func (c *queuedTraceClient) Stop(ctx context.Context) error {
+	c.queue.Close()
+	return c.Client.Stop(ctx)
+}
+
+// healthTraceClient records the outcome of every span upload.
+type healthTraceClient struct {
+	otlptrace.Client
+	health *ExportHealth
+}
+
+func (c *healthTraceClient) UploadTraces(ctx context.Context, spans []*tracepb.ResourceSpans) error {
+	err := c.Client.UploadTraces(ctx, spans)
+	c.health.Record(err)
+	return err
+}
+
+// queuedLogExporter queues log exports that fail.
+type queuedLogExporter struct {
+	sdklog.Exporter
+	queue *ExportQueue
+}
+
+// newQueuedLogExporter wraps exporter with a queue under cfg.Dir.
+func newQueuedLogExporter(exporter sdklog.Exporter, cfg config.QueueConfig) (*queuedLogExporter, error) {
+	e := &queuedLogExporter{Exporter: exporter}
+	q, err := NewExportQueue(cfg, "logs", func(ctx context.Context, payload []byte) error {
+		var ld logspb.LogsData
+		if err := proto.Unmarshal(payload, &ld); err != nil {
+			return &permanentError{err: err}
+		}
+		return e.Exporter.Export(ctx, logRecords(ld.ResourceLogs))
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.queue = q
+	return e, nil
+}
+
+func (e *queuedLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	return e.queue.export(ctx, len(records),
+		func(ctx context.Context) error { return e.Exporter.Export(ctx, records) },
+		func() ([]byte, error) { return proto.Marshal(&logspb.LogsData{ResourceLogs: resourceLogs(records)}) },
+	)
+}
+
+func (e *queuedLogExporter) Shutdown(ctx context.Context) error {
+	e.queue.Close()
+	return e.Exporter.Shutdown(ctx)
+}
+
+// queuedMetricExporter queues metric exports that fail.
+type queuedMetricExporter struct {
+	sdkmetric.Exporter
+	queue *ExportQueue
+}
+
+// newQueuedMetricExporter wraps exporter with a queue under cfg.Dir.
+func newQueuedMetricExporter(exporter sdkmetric.Exporter, cfg config.QueueConfig) (*queuedMetricExporter, error) {
+	e := &queuedMetricExporter{Exporter: exporter}
+	q, err := NewExportQueue(cfg, "metrics", func(ctx context.Context, payload []byte) error {
+		var md metricspb.MetricsData
+		if err := proto.Unmarshal(payload, &md); err != nil {
+			return &permanentError{err: err}
+		}
+		var errs []error
+		for _, rm := range md.ResourceMetrics {
+			data, err := resourceMetricsData(rm)
+			if err != nil {
+				return &permanentError{err: err}
+			}
+			errs = append(errs, e.Exporter.Export(ctx, data))
+		}
+		return errors.Join(errs...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.queue = q
+	return e, nil
+}
+
+func (e *queuedMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	items := 0
+	for _, sm := range rm.ScopeMetrics {
+		items += len(sm.Metrics)
+	}
+	return e.queue.export(ctx, items,
+		func(ctx context.Context) error { return e.Exporter.Export(ctx, rm) },
+		func() ([]byte, error) {
+			pm, err := resourceMetricsProto(rm)
+			if err != nil {
+				otel.Handle(err) // unsupported metrics are left out; store the rest
+			}
+			return proto.Marshal(&metricspb.MetricsData{ResourceMetrics: []*metricspb.ResourceMetrics{pm}})
+		},
+	)
+}
+
+func (e *queuedMetricExporter) Shutdown(ctx context.Context) error {
+	e.queue.Close()
+	return e.Exporter.Shutdown(ctx)
+}
@@ -0,0 +1,434 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+// flakyEndpoint records payloads and fails while down is set.
+type flakyEndpoint struct {
+	mu   sync.Mutex
+	down bool
+	got  []string
+}
+
+func (e *flakyEndpoint) setDown(down bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.down = down
+}
+
+func (e *flakyEndpoint) send(_ context.Context, payload []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.down {
+		return errors.New("connection refused")
+	}
+	e.got = append(e.got, string(payload))
+	return nil
+}
+
+func (e *flakyEndpoint) received() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.got...)
+}
+
+func exportString(q *ExportQueue, ep *flakyEndpoint, payload string) error {
+	return q.export(context.Background(), 1,
+		func(ctx context.Context) error { return ep.send(ctx, []byte(payload)) },
+		func() ([]byte, error) { return []byte(payload), nil },
+	)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 2s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestExportQueue_ReplaysInOrder(t *testing.T) {
+	ep := &flakyEndpoint{down: true}
+	q, err := NewExportQueue(config.QueueConfig{Dir: t.TempDir(), RetryInterval: 10 * time.Millisecond}, "logs", ep.send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	for _, p := range []string{"a", "b"} {
+		if err := exportString(q, ep, p); err != nil {
+			t.Fatalf("export(%q) = %v, want nil once stored", p, err)
+		}
+	}
+	if got := q.Stats(); got.Queued != 2 || got.Pending != 2 {
+		t.Errorf("while down: %+v, want 2 queued and pending", got)
+	}
+
+	// Recovered: "c" must wait behind the stored batches.
+	ep.setDown(false)
+	if err := exportString(q, ep, "c"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return q.Stats().Pending == 0 })
+
+	if got := strings.Join(ep.received(), ","); got != "a,b,c" {
+		t.Errorf("received %q, want a,b,c", got)
+	}
+	if got := q.Stats(); got.Queued != 3 || got.Replayed != 3 || got.Dropped != 0 {
+		t.Errorf("after recovery: %+v, want 3 queued and replayed", got)
+	}
+
+	// With the queue empty, batches go out directly.
+	if err := exportString(q, ep, "d"); err != nil {
+		t.Fatal(err)
+	}
+	if got := q.Stats().Queued; got != 3 {
+		t.Errorf("Queued = %d after a direct send, want 3", got)
+	}
+}
+
+func TestExportQueue_SizeCapAndAge(t *testing.T) {
+	ep := &flakyEndpoint{down: true}
+	q, err := NewExportQueue(config.QueueConfig{Dir: t.TempDir(), MaxSizeMB: 1, MaxAge: 50 * time.Millisecond, RetryInterval: time.Hour}, "metrics", ep.send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	big := strings.Repeat("x", 400<<10)
+	for i := 0; i < 3; i++ {
+		if err := exportString(q, ep, big); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := q.Stats(); got.Dropped != 1 || got.Pending != 2 {
+		t.Errorf("over the cap: %+v, want the oldest dropped", got)
+	}
+
+	if err := exportString(q, ep, strings.Repeat("x", 2<<20)); err == nil {
+		t.Error("export of a batch larger than the cap = nil, want error")
+	}
+	if got := q.Stats().Dropped; got != 2 {
+		t.Errorf("Dropped = %d after an oversized batch, want 2", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	ep.setDown(false)
+	q.drain(context.Background())
+	if got := q.Stats(); got.Dropped != 4 || got.Pending != 0 || got.Replayed != 0 {
+		t.Errorf("after MaxAge: %+v, want expired batches dropped, not replayed", got)
+	}
+	if got := len(ep.received()); got != 0 {
+		t.Errorf("endpoint received %d batches, want 0", got)
+	}
+}
+
+func TestExportQueue_SurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	down := &flakyEndpoint{down: true}
+	q, err := NewExportQueue(config.QueueConfig{Dir: dir, RetryInterval: time.Hour}, "traces", down.send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"a", "b"} {
+		if err := exportString(q, down, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	q.Close()
+
+	// A partial write from a crash is discarded, not replayed.
+	if err := os.WriteFile(filepath.Join(dir, "traces", "00000000000000000009.batch.tmp"), []byte("partial"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	up := &flakyEndpoint{}
+	q, err = NewExportQueue(config.QueueConfig{Dir: dir, RetryInterval: time.Hour}, "traces", up.send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+	waitFor(t, func() bool { return q.Stats().Pending == 0 })
+	if got := strings.Join(up.received(), ","); got != "a,b" {
+		t.Errorf("replayed %q after restart, want a,b", got)
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, "traces"))
+	if len(entries) != 0 {
+		t.Errorf("%d files left in the queue directory, want 0", len(entries))
+	}
+}
+
+// flakyLogExporter fails while down is set and keeps the records it accepts.
+type flakyLogExporter struct {
+	sdklog.Exporter
+	mu      sync.Mutex
+	down    bool
+	records []sdklog.Record
+}
+
+func (e *flakyLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.down {
+		return errors.New("connection refused")
+	}
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *flakyLogExporter) Shutdown(context.Context) error { return nil }
+
+func TestQueuedLogExporter_RoundTrip(t *testing.T) {
+	capture := &recordCapture{}
+	res := resource.NewSchemaless(attribute.String("service.name", "payments"))
+	provider := sdklog.NewLoggerProvider(sdklog.WithResource(res), sdklog.WithProcessor(capture))
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+	var rec log.Record
+	rec.SetTimestamp(time.Unix(1700000000, 0))
+	rec.SetSeverity(log.SeverityWarn)
+	rec.SetBody(log.StringValue("slot missed"))
+	rec.AddAttributes(log.Int64("slot", 42), log.Map("peer", log.String("id", "p1")))
+	provider.Logger("ion").Emit(trace.ContextWithSpanContext(context.Background(), sc), rec)
+
+	inner := &flakyLogExporter{down: true}
+	exp, err := newQueuedLogExporter(inner, config.QueueConfig{Dir: t.TempDir(), RetryInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = exp.Shutdown(context.Background()) }()
+
+	if err := exp.Export(context.Background(), capture.records); err != nil {
+		t.Fatal(err)
+	}
+	inner.mu.Lock()
+	inner.down = false
+	inner.mu.Unlock()
+	waitFor(t, func() bool { return exp.queue.Stats().Replayed == 1 })
+
+	got := inner.records[0]
+	if got.Body().AsString() != "slot missed" || got.Severity() != log.SeverityWarn {
+		t.Errorf("replayed body %v severity %v", got.Body(), got.Severity())
+	}
+	if got.TraceID() != sc.TraceID() || got.SpanID() != sc.SpanID() || got.TraceFlags() != trace.FlagsSampled {
+		t.Errorf("replayed trace context %s/%s/%s, want %s/%s/01", got.TraceID(), got.SpanID(), got.TraceFlags(), sc.TraceID(), sc.SpanID())
+	}
+	if !got.Timestamp().Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Timestamp() = %v", got.Timestamp())
+	}
+	var attrs []string
+	got.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs = append(attrs, kv.Key+"="+kv.Value.String())
+		return true
+	})
+	if s := strings.Join(attrs, " "); s != "slot=42 peer=[id:p1]" {
+		t.Errorf("replayed attributes %q", s)
+	}
+	if v, _ := got.Resource().Set().Value("service.name"); v.AsString() != "payments" {
+		t.Errorf("replayed resource %v", got.Resource())
+	}
+	if got.InstrumentationScope().Name != "ion" {
+		t.Errorf("replayed scope %q, want ion", got.InstrumentationScope().Name)
+	}
+}
+
+func TestResourceMetricsData_RoundTrip(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	meter := mp.Meter("ion")
+	counter, _ := meter.Int64Counter("blocks")
+	counter.Add(context.Background(), 3)
+	hist, _ := meter.Float64Histogram("latency")
+	hist.Record(context.Background(), 0.25)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	pm, err := resourceMetricsProto(&rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := resourceMetricsData(pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := back.ScopeMetrics[0].Metrics
+	sum, ok := metrics[0].Data.(metricdata.Sum[int64])
+	if !ok || sum.DataPoints[0].Value != 3 || !sum.IsMonotonic || sum.Temporality != metricdata.CumulativeTemporality {
+		t.Errorf("blocks = %#v, want a cumulative monotonic int sum of 3", metrics[0].Data)
+	}
+	h, ok := metrics[1].Data.(metricdata.Histogram[float64])
+	if !ok || h.DataPoints[0].Count != 1 || h.DataPoints[0].Sum != 0.25 {
+		t.Errorf("latency = %#v, want one 0.25 observation", metrics[1].Data)
+	}
+	if max, ok := h.DataPoints[0].Max.Value(); !ok || max != 0.25 {
+		t.Errorf("latency max = %v, %v", max, ok)
+	}
+}
+
+// flakyTraceClient fails uploads while down is set.
+type flakyTraceClient struct {
+	mu    sync.Mutex
+	down  bool
+	spans []string
+}
+
+func (c *flakyTraceClient) Start(context.Context) error { return nil }
+func (c *flakyTraceClient) Stop(context.Context) error  { return nil }
+
+func (c *flakyTraceClient) UploadTraces(_ context.Context, rss []*tracepb.ResourceSpans) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return errors.New("connection refused")
+	}
+	for _, rs := range rss {
+		for _, ss := range rs.ScopeSpans {
+			for _, s := range ss.Spans {
+				c.spans = append(c.spans, s.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func TestQueuedTraceClient_RecordsHealth(t *testing.T) {
+	inner := &flakyTraceClient{down: true}
+	health := &ExportHealth{}
+	client, err := newQueuedTraceClient(&healthTraceClient{Client: inner, health: health}, config.QueueConfig{Dir: t.TempDir(), RetryInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Stop(context.Background()) }()
+
+	spans := []*tracepb.ResourceSpans{{ScopeSpans: []*tracepb.ScopeSpans{{Spans: []*tracepb.Span{{Name: "commit"}, {Name: "vote"}}}}}}
+	if err := client.UploadTraces(context.Background(), spans); err != nil {
+		t.Fatalf("UploadTraces() = %v, want nil once stored", err)
+	}
+	if snap := health.Snapshot(); snap.Failures != 1 || snap.Healthy() {
+		t.Errorf("health while down = %+v, want the failed send recorded", snap)
+	}
+	if got := client.queue.Stats().Queued; got != 2 {
+		t.Errorf("Queued = %d, want 2 spans", got)
+	}
+
+	inner.mu.Lock()
+	inner.down = false
+	inner.mu.Unlock()
+	waitFor(t, func() bool { return client.queue.Stats().Replayed == 2 })
+	if snap := health.Snapshot(); !snap.Healthy() {
+		t.Errorf("health after replay = %+v, want healthy", snap)
+	}
+	inner.mu.Lock()
+	defer inner.mu.Unlock()
+	if got := strings.Join(inner.spans, ","); got != "commit,vote" {
+		t.Errorf("replayed spans %q, want commit,vote", got)
+	}
+}
+
+func TestQueuedTraceClient_DropsCorruptBatch(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "traces"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	// A batch of 3 spans whose payload no longer decodes, left by an earlier run.
+	corrupt := make([]byte, queueHeaderSize, queueHeaderSize+4)
+	binary.BigEndian.PutUint64(corrupt[:8], uint64(time.Now().UnixNano())) //nolint:gosec // Current time is after 1970
+	binary.BigEndian.PutUint32(corrupt[8:], 3)
+	corrupt = append(corrupt, 0xff, 0xff, 0xff, 0xff)
+	if err := writeFileSync(filepath.Join(dir, "traces", "00000000000000000000"+queueFileExt), corrupt); err != nil {
+		t.Fatal(err)
+	}
+
+	inner := &flakyTraceClient{down: true}
+	client, err := newQueuedTraceClient(inner, config.QueueConfig{Dir: dir, RetryInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Stop(context.Background()) }()
+
+	// Queued behind the corrupt batch while the endpoint is down.
+	spans := []*tracepb.ResourceSpans{{ScopeSpans: []*tracepb.ScopeSpans{{Spans: []*tracepb.Span{{Name: "commit"}}}}}}
+	if err := client.UploadTraces(context.Background(), spans); err != nil {
+		t.Fatalf("UploadTraces() = %v, want nil once stored", err)
+	}
+
+	inner.mu.Lock()
+	inner.down = false
+	inner.mu.Unlock()
+	waitFor(t, func() bool { return client.queue.Stats().Replayed == 1 })
+	if s := client.queue.Stats(); s.Dropped != 3 || s.Pending != 0 {
+		t.Errorf("stats = %+v, want the corrupt batch's 3 spans dropped and nothing pending", s)
+	}
+}
+
+func TestExportQueue_PermanentRejection(t *testing.T) {
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{errors.New("dial tcp 127.0.0.1:4318: connect: connection refused"), true},
+		{status.Error(grpccodes.Unavailable, "connection refused"), true},
+		{fmt.Errorf("max retry time elapsed: %w", status.Error(grpccodes.ResourceExhausted, "slow down")), true},
+		{status.Error(grpccodes.InvalidArgument, "bad span"), false},
+		{errors.New("failed to send logs to http://collector:4318/v1/logs: 400 Bad Request (body: invalid)"), false},
+		{errors.New("failed to send to http://collector:4318/v1/traces: 413 Request Entity Too Large (body: (empty))"), false},
+		{&permanentError{err: errors.New("proto: cannot parse invalid wire-format data")}, false},
+	}
+	for _, tt := range tests {
+		if got := retryable(tt.err); got != tt.retryable {
+			t.Errorf("retryable(%v) = %v, want %v", tt.err, got, tt.retryable)
+		}
+	}
+
+	var sent []string
+	q, err := NewExportQueue(config.QueueConfig{Dir: t.TempDir(), RetryInterval: 10 * time.Millisecond}, "logs", func(context.Context, []byte) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+	rejected := status.Error(grpccodes.InvalidArgument, "bad record")
+	err = q.export(context.Background(), 2,
+		func(context.Context) error { return rejected },
+		func() ([]byte, error) { sent = append(sent, "stored"); return []byte("x"), nil },
+	)
+	if !errors.Is(err, rejected) {
+		t.Errorf("export() = %v, want the rejection", err)
+	}
+	if s := q.Stats(); s.Queued != 0 || s.Dropped != 2 || len(sent) != 0 {
+		t.Errorf("stats = %+v, stored %v; want a rejected batch dropped, not stored", s, sent)
+	}
+}
@@ -0,0 +1,240 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+// Redaction actions for key rules.
+const (
+	RedactDrop = "drop" // remove the field
+	RedactMask = "mask" // replace the value with RedactedValue
+	RedactHash = "hash" // replace the value with a salted, truncated HMAC-SHA256
+)
+
+// RedactedValue replaces masked values.
+const RedactedValue = "[REDACTED]"
+
+// detector finds sensitive content inside string values.
+type detector struct {
+	name string
+	re   *regexp.Regexp
+	// whole requires the entire value to match (used for seed phrases,
+	// where a substring match would hit ordinary prose).
+	whole bool
+}
+
+// seedWordCounts are the BIP-39 mnemonic lengths.
+var seedWordCounts = map[int]bool{12: true, 15: true, 18: true, 21: true, 24: true}
+
+// detectors maps detector names accepted in RedactionConfig.Detectors to their patterns.
+var detectors = map[string]detector{
+	"private_key": {name: "private_key", re: regexp.MustCompile(`\b(?:0x)?[0-9a-fA-F]{64}\b`)},
+	"seed_phrase": {name: "seed_phrase", re: regexp.MustCompile(`^\s*[a-z]{3,8}(?:\s+[a-z]{3,8}){11,23}\s*$`), whole: true},
+	"jwt":         {name: "jwt", re: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)},
+	"email":       {name: "email", re: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+}
+
+// Redactor applies key rules and value detectors to log fields.
+// It is immutable after construction and safe for concurrent use.
+type Redactor struct {
+	keys      map[string]string // lowercased key -> action
+	exempt    map[string]bool   // lowercased keys skipped by detectors
+	detectors []detector
+	salt      []byte
+}
+
+// NewRedactor builds a Redactor from cfg. Returns nil if redaction is disabled
+// or configures nothing.
+func NewRedactor(cfg config.RedactionConfig) (*Redactor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	r := &Redactor{
+		keys:   make(map[string]string, len(cfg.Keys)),
+		exempt: make(map[string]bool, len(cfg.Exempt)),
+		salt:   []byte(cfg.Salt),
+	}
+	for k, action := range cfg.Keys {
+		action = strings.ToLower(action)
+		switch action {
+		case RedactDrop, RedactMask:
+		case RedactHash:
+			if cfg.Salt == "" {
+				return nil, fmt.Errorf("redaction key %q uses hash but no salt is set", k)
+			}
+		default:
+			return nil, fmt.Errorf("invalid redaction action %q for key %q (use: drop, mask, hash)", action, k)
+		}
+		r.keys[strings.ToLower(k)] = action
+	}
+	for _, k := range cfg.Exempt {
+		r.exempt[strings.ToLower(k)] = true
+	}
+	for _, name := range cfg.Detectors {
+		d, ok := detectors[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction detector %q", name)
+		}
+		r.detectors = append(r.detectors, d)
+	}
+	if len(r.keys) == 0 && len(r.detectors) == 0 {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// RedactionApplies reports whether redaction is configured for the named output
+// ("console", "file", "otel"). An empty output list means every output.
+func RedactionApplies(cfg config.RedactionConfig, output string) bool {
+	if len(cfg.Outputs) == 0 {
+		return true
+	}
+	for _, o := range cfg.Outputs {
+		if strings.EqualFold(o, output) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns fields with rules applied. The input slice is never modified;
+// when nothing matches it is returned as is.
+func (r *Redactor) Redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		nf, keep, changed := r.redactField(f)
+		if !changed && out == nil {
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, 0, len(fields))
+			out = append(out, fields[:i]...)
+		}
+		if keep {
+			out = append(out, nf)
+		}
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// redactField returns the replacement field, whether to keep it, and whether
+// it differs from f.
+func (r *Redactor) redactField(f zapcore.Field) (zapcore.Field, bool, bool) {
+	if action, ok := r.keys[strings.ToLower(f.Key)]; ok {
+		switch action {
+		case RedactDrop:
+			return f, false, true
+		case RedactMask:
+			return zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: RedactedValue}, true, true
+		default:
+			return zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: r.hash(fieldString(f))}, true, true
+		}
+	}
+
+	if len(r.detectors) == 0 || r.exempt[strings.ToLower(f.Key)] {
+		return f, true, false
+	}
+
+	var s string
+	switch f.Type {
+	case zapcore.StringType:
+		s = f.String
+	case zapcore.ByteStringType:
+		s = string(f.Interface.([]byte))
+	case zapcore.ErrorType:
+		err, ok := f.Interface.(error)
+		if !ok || err == nil {
+			return f, true, false
+		}
+		s = err.Error()
+	case zapcore.StringerType, zapcore.ArrayMarshalerType, zapcore.ObjectMarshalerType, zapcore.ReflectType:
+		// Composite values are scanned in their rendered form; a match
+		// replaces the whole value with the scrubbed string.
+		s = fieldString(f)
+	default:
+		return f, true, false
+	}
+	if red, changed := r.scrub(s); changed {
+		return zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: red}, true, true
+	}
+	return f, true, false
+}
+
+// scrub replaces detector matches in s with "[REDACTED:<detector>]".
+func (r *Redactor) scrub(s string) (string, bool) {
+	changed := false
+	for _, d := range r.detectors {
+		if d.whole {
+			if d.re.MatchString(s) && seedWordCounts[len(strings.Fields(s))] {
+				return "[REDACTED:" + d.name + "]", true
+			}
+			continue
+		}
+		if d.re.MatchString(s) {
+			s = d.re.ReplaceAllLiteralString(s, "[REDACTED:"+d.name+"]")
+			changed = true
+		}
+	}
+	return s, changed
+}
+
+// hash returns a stable, salted pseudonym for v so redacted values can still
+// be correlated across log lines without being reversible.
+func (r *Redactor) hash(v string) string {
+	mac := hmac.New(sha256.New, r.salt)
+	mac.Write([]byte(v))
+	return "sha256:" + hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// fieldString renders any field's value as a string for hashing.
+func fieldString(f zapcore.Field) string {
+	if f.Type == zapcore.StringType {
+		return f.String
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return fmt.Sprint(enc.Fields[f.Key])
+}
+
+// redactingCore applies a Redactor to per-call and With() fields
+// before they reach the wrapped output.
+type redactingCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+// NewRedactingCore wraps an output core so its fields are redacted.
+// If r is nil the core is returned unchanged.
+func NewRedactingCore(core zapcore.Core, r *Redactor) zapcore.Core {
+	if r == nil {
+		return core
+	}
+	return &redactingCore{Core: core, redactor: r}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redactor.Redact(fields)), redactor: c.redactor}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.redactor.Redact(fields))
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+func newTestRedactor(t *testing.T, cfg config.RedactionConfig) *Redactor {
+	t.Helper()
+	cfg.Enabled = true
+	r, err := NewRedactor(cfg)
+	if err != nil {
+		t.Fatalf("NewRedactor() error: %v", err)
+	}
+	return r
+}
+
+func TestRedactor_KeyRules(t *testing.T) {
+	r := newTestRedactor(t, config.RedactionConfig{
+		Keys: map[string]string{"private_key": "drop", "TX_SIGNATURE": "mask", "user_id": "hash"},
+		Salt: "s1",
+	})
+
+	in := []zapcore.Field{
+		zap.String("private_key", "deadbeef"),
+		zap.String("tx_signature", "5KQw"),
+		zap.String("user_id", "alice"),
+		zap.Int("count", 3),
+	}
+	out := r.Redact(in)
+
+	got := zapcore.NewMapObjectEncoder()
+	for _, f := range out {
+		f.AddTo(got)
+	}
+	if _, ok := got.Fields["private_key"]; ok {
+		t.Error("private_key should be dropped")
+	}
+	if got.Fields["tx_signature"] != RedactedValue {
+		t.Errorf("tx_signature = %v, want %q", got.Fields["tx_signature"], RedactedValue)
+	}
+	hashed, _ := got.Fields["user_id"].(string)
+	if !strings.HasPrefix(hashed, "sha256:") || strings.Contains(hashed, "alice") {
+		t.Errorf("user_id = %q, want salted hash", hashed)
+	}
+	if got.Fields["count"] != int64(3) {
+		t.Errorf("count = %v, want 3", got.Fields["count"])
+	}
+	if in[0].String != "deadbeef" {
+		t.Error("Redact must not modify its input")
+	}
+
+	// Same salt hashes identically; a different salt does not.
+	again := newTestRedactor(t, config.RedactionConfig{Keys: map[string]string{"user_id": "hash"}, Salt: "s1"})
+	other := newTestRedactor(t, config.RedactionConfig{Keys: map[string]string{"user_id": "hash"}, Salt: "s2"})
+	if h := again.Redact([]zapcore.Field{zap.String("user_id", "alice")})[0].String; h != hashed {
+		t.Errorf("hash with same salt = %q, want %q", h, hashed)
+	}
+	if h := other.Redact([]zapcore.Field{zap.String("user_id", "alice")})[0].String; h == hashed {
+		t.Error("hash with different salt should differ")
+	}
+}
+
+func TestRedactor_Detectors(t *testing.T) {
+	r := newTestRedactor(t, config.RedactionConfig{
+		Detectors: []string{"private_key", "seed_phrase", "jwt", "email"},
+		Exempt:    []string{"tx_hash"},
+	})
+	key := strings.Repeat("ab", 32)
+	seed := "abandon ability able about above absent absorb abstract absurd abuse access accident"
+
+	tests := []struct {
+		name  string
+		field zapcore.Field
+		want  string
+	}{
+		{"private key", zap.String("note", "key=0x"+key), "key=[REDACTED:private_key]"},
+		{"exempt key", zap.String("tx_hash", key), key},
+		{"seed phrase", zap.String("backup", seed), "[REDACTED:seed_phrase]"},
+		{"prose is not a seed", zap.String("note", "the peer closed the connection"), "the peer closed the connection"},
+		{"jwt", zap.String("auth", "Bearer eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig_abc"), "Bearer [REDACTED:jwt]"},
+		{"email", zap.String("contact", "mail ops@example.com now"), "mail [REDACTED:email] now"},
+		{"error value", zap.NamedError("err", errors.New("user bob@example.com not found")), "user [REDACTED:email] not found"},
+		{"string slice", zap.Strings("contacts", []string{"ops", "ops@example.com"}), "[ops [REDACTED:email]]"},
+		{"any struct", zap.Any("peer", struct{ Token string }{"eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig_abc"}), "{[REDACTED:jwt]}"},
+		{"stringer", zap.Stringer("url", stringer("https://ops@example.com/rpc")), "https://[REDACTED:email]/rpc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := r.Redact([]zapcore.Field{tt.field})
+			enc := zapcore.NewMapObjectEncoder()
+			out[0].AddTo(enc)
+			if got := enc.Fields[tt.field.Key]; got != tt.want {
+				t.Errorf("got %v, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Unmatched composites keep their type; numbers are never scanned.
+	in := []zapcore.Field{zap.Strings("peers", []string{"a", "b"}), zap.Uint64("nonce", 1)}
+	if out := r.Redact(in); out[0].Type != zapcore.ArrayMarshalerType || out[1] != in[1] {
+		t.Errorf("Redact(%v) = %v, want fields unchanged", in, out)
+	}
+}
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+func TestNewRedactor_Invalid(t *testing.T) {
+	if _, err := NewRedactor(config.RedactionConfig{Enabled: true, Keys: map[string]string{"k": "shred"}}); err == nil {
+		t.Error("expected error for unknown action")
+	}
+	if _, err := NewRedactor(config.RedactionConfig{Enabled: true, Detectors: []string{"ssn"}}); err == nil {
+		t.Error("expected error for unknown detector")
+	}
+	if _, err := NewRedactor(config.RedactionConfig{Enabled: true, Keys: map[string]string{"k": "hash"}}); err == nil {
+		t.Error("expected error for hash without a salt")
+	}
+	if r, _ := NewRedactor(config.RedactionConfig{Keys: map[string]string{"k": "drop"}}); r != nil {
+		t.Error("disabled config should return a nil Redactor")
+	}
+}
+
+func TestRedactingCore_With(t *testing.T) {
+	obs, logs := observer.New(zapcore.DebugLevel)
+	r := newTestRedactor(t, config.RedactionConfig{Keys: map[string]string{"address": "mask"}})
+	logger := zap.New(NewRedactingCore(obs, r))
+
+	logger.With(zap.String("address", "0xabc")).Info("bound")
+	logger.Info("per call", zap.String("address", "0xdef"))
+
+	for _, entry := range logs.All() {
+		if got := entry.ContextMap()["address"]; got != RedactedValue {
+			t.Errorf("%s: address = %v, want %q", entry.Message, got, RedactedValue)
+		}
+	}
+}
+
+func TestRedactionApplies(t *testing.T) {
+	cfg := config.RedactionConfig{Enabled: true, Outputs: []string{"file", "otel"}}
+	if !RedactionApplies(cfg, "file") || !RedactionApplies(cfg, "OTEL") {
+		t.Error("redaction should apply to file and otel")
+	}
+	if RedactionApplies(cfg, "console") {
+		t.Error("redaction should not apply to console")
+	}
+	cfg.Outputs = nil
+	if !RedactionApplies(cfg, "console") {
+		t.Error("empty Outputs should apply to every output")
+	}
+}
+
+func TestNewZapLogger_RedactsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	cfg := config.Default()
+	cfg.Console.Enabled = false
+	cfg.File.Enabled = true
+	cfg.File.Path = path
+	cfg.Redaction = config.RedactionConfig{Enabled: true, Keys: map[string]string{"secret": "drop"}, Outputs: []string{"file"}}
+
+	res, err := NewZapLogger(cfg, nil, Components{})
+	if err != nil {
+		t.Fatalf("NewZapLogger() error: %v", err)
+	}
+	res.Logger.With(zap.String("secret", "bound-value")).Info("hello", zap.String("secret", "call-value"))
+	_ = res.Logger.Sync()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	if !strings.Contains(string(data), "hello") {
+		t.Fatalf("log file missing entry: %s", data)
+	}
+	if strings.Contains(string(data), "value") {
+		t.Errorf("log file contains redacted values: %s", data)
+	}
+}
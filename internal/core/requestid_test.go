@@ -0,0 +1,45 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewRequestID(t *testing.T) {
+	first := NewRequestID()
+	time.Sleep(2 * time.Millisecond)
+	second := NewRequestID()
+
+	for _, id := range []string{first, second} {
+		if len(id) != 26 || strings.Trim(id, crockford) != "" {
+			t.Errorf("NewRequestID() = %q, want 26 Crockford base32 characters", id)
+		}
+	}
+	if first >= second {
+		t.Errorf("IDs not time-ordered: %q >= %q", first, second)
+	}
+	if a, b := NewRequestID(), NewRequestID(); a == b {
+		t.Errorf("two IDs are equal: %q", a)
+	}
+}
+
+func TestValidRequestID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"01JA2B3C4D5E6F7G8H9J0KMNPQ", true},
+		{"req-42/a.b", true},
+		{"", false},
+		{"has space", false},
+		{"new\nline", false},
+		{"ünicode", false},
+		{strings.Repeat("a", 129), false},
+	}
+	for _, tt := range tests {
+		if got := ValidRequestID(tt.id); got != tt.want {
+			t.Errorf("ValidRequestID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
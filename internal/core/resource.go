@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
+)
+
+// NewResource builds the OTEL resource shared by logs, traces, and metrics.
+// Every signal reports the same service identity, host, OS, and process
+// attributes; attrs adds custom attributes on top (e.g. environment, chain).
+//
+// When detection fails, the error is returned with a resource that still
+// carries at least the service identity and attrs.
+func NewResource(ctx context.Context, serviceName, version string, attrs map[string]string) (*resource.Resource, error) {
+	kvs := append([]attribute.KeyValue{
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	}, sortedAttributes(attrs)...)
+
+	res, err := resource.New(ctx,
+		resource.WithHost(),
+		resource.WithOS(),
+		resource.WithProcess(),
+		resource.WithAttributes(kvs...),
+	)
+	if err != nil {
+		if res == nil {
+			res = resource.NewSchemaless(kvs...)
+		}
+		return res, fmt.Errorf("OTEL resource detection failed: %w", err)
+	}
+	return res, nil
+}
+
+// signalResource returns base with one signal's attrs and then extra.Resource
+// merged over it. Detection runs once for base, so every signal reports the
+// same host and process. Differing schema URLs are not an error; the merged
+// resource is then schemaless.
+func signalResource(base *resource.Resource, attrs map[string]string, extra Components) (*resource.Resource, error) {
+	res := base
+	if res == nil {
+		res = resource.Empty()
+	}
+	for _, r := range []*resource.Resource{resource.NewSchemaless(sortedAttributes(attrs)...), extra.Resource} {
+		if r == nil || r.Len() == 0 {
+			continue
+		}
+		merged, err := resource.Merge(res, r)
+		if err != nil && !errors.Is(err, resource.ErrSchemaURLConflict) {
+			return nil, fmt.Errorf("failed to merge OTEL resource: %w", err)
+		}
+		res = merged
+	}
+	return res, nil
+}
+
+// sortedAttributes returns attrs as string attributes, sorted by key so the
+// resource is identical across runs and signals.
+func sortedAttributes(attrs map[string]string) []attribute.KeyValue {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, attribute.String(k, attrs[k]))
+	}
+	return kvs
+}
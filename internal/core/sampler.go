@@ -0,0 +1,104 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+// NewSampler builds the head sampler from TracingConfig.Sampler and
+// TracingConfig.SamplerRules. Rules are consulted before the base sampler;
+// with a parentbased_ sampler, both apply only to root spans.
+func NewSampler(spec string, rules []config.SamplerRule) (sdktrace.Sampler, error) {
+	base, err := config.ParseSampler(spec)
+	if err != nil {
+		return nil, err
+	}
+	root := samplerFromSpec(base)
+
+	if len(rules) > 0 {
+		rs := &ruleSampler{fallback: root}
+		for i, r := range rules {
+			s, err := config.ParseSampler(r.Sampler)
+			if err != nil {
+				return nil, fmt.Errorf("sampler rule %d: %w", i, err)
+			}
+			cr := samplerRule{sampler: samplerFromSpec(s)}
+			cr.name, cr.prefix = strings.CutSuffix(r.Name, "*")
+			if r.Attribute != "" {
+				k, v, _ := strings.Cut(r.Attribute, "=")
+				cr.attrKey = attribute.Key(strings.TrimSpace(k))
+				cr.attrValue = strings.TrimSpace(v)
+			}
+			rs.rules = append(rs.rules, cr)
+		}
+		root = rs
+	}
+
+	if base.ParentBased {
+		return sdktrace.ParentBased(root), nil
+	}
+	return root, nil
+}
+
+func samplerFromSpec(spec config.SamplerSpec) sdktrace.Sampler {
+	switch spec.Kind {
+	case "never":
+		return sdktrace.NeverSample()
+	case "ratio":
+		return sdktrace.TraceIDRatioBased(spec.Ratio)
+	default:
+		return sdktrace.AlwaysSample()
+	}
+}
+
+// ruleSampler picks a sampler by span name or start attribute.
+type ruleSampler struct {
+	rules    []samplerRule
+	fallback sdktrace.Sampler
+}
+
+type samplerRule struct {
+	name      string
+	prefix    bool // name ended in "*"
+	attrKey   attribute.Key
+	attrValue string
+	sampler   sdktrace.Sampler
+}
+
+func (r samplerRule) matches(p sdktrace.SamplingParameters) bool {
+	if r.name != "" || r.prefix {
+		if r.prefix && !strings.HasPrefix(p.Name, r.name) {
+			return false
+		}
+		if !r.prefix && p.Name != r.name {
+			return false
+		}
+	}
+	if r.attrKey != "" {
+		for _, kv := range p.Attributes {
+			if kv.Key == r.attrKey && kv.Value.Emit() == r.attrValue {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (s *ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, r := range s.rules {
+		if r.matches(p) {
+			return r.sampler.ShouldSample(p)
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *ruleSampler) Description() string {
+	return fmt.Sprintf("RuleSampler{rules:%d,fallback:%s}", len(s.rules), s.fallback.Description())
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+func sampled(ctx context.Context, s sdktrace.Sampler, name string, attrs ...attribute.KeyValue) bool {
+	res := s.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: ctx,
+		TraceID:       trace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Name:          name,
+		Attributes:    attrs,
+	})
+	return res.Decision == sdktrace.RecordAndSample
+}
+
+func remoteParent(sampled bool) context.Context {
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: flags,
+		Remote:     true,
+	})
+	return trace.ContextWithRemoteSpanContext(context.Background(), sc)
+}
+
+func TestNewSampler_ParentBased(t *testing.T) {
+	s, err := NewSampler("parentbased_ratio:0", nil)
+	if err != nil {
+		t.Fatalf("NewSampler() error: %v", err)
+	}
+	if !sampled(remoteParent(true), s, "op") {
+		t.Error("sampled parent should be followed")
+	}
+	if sampled(remoteParent(false), s, "op") {
+		t.Error("unsampled parent should be followed")
+	}
+	if sampled(context.Background(), s, "op") {
+		t.Error("root span should use ratio 0")
+	}
+
+	// A root sampler ignores the parent.
+	s, _ = NewSampler("never", nil)
+	if sampled(remoteParent(true), s, "op") {
+		t.Error("never should ignore a sampled parent")
+	}
+}
+
+func TestNewSampler_Rules(t *testing.T) {
+	s, err := NewSampler("ratio:0", []config.SamplerRule{
+		{Name: "/tx.Submit", Sampler: "always"},
+		{Name: "/debug/*", Sampler: "always"},
+		{Attribute: "url.path=/health", Sampler: "never"},
+		{Name: "op", Attribute: "chain=solana", Sampler: "always"},
+	})
+	if err != nil {
+		t.Fatalf("NewSampler() error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		attrs []attribute.KeyValue
+		want  bool
+	}{
+		{"/tx.Submit", nil, true},
+		{"/debug/pprof", nil, true},
+		{"/tx.Query", nil, false},
+		{"GET", []attribute.KeyValue{attribute.String("url.path", "/health")}, false},
+		{"op", []attribute.KeyValue{attribute.String("chain", "solana")}, true},
+		{"op", []attribute.KeyValue{attribute.String("chain", "eth")}, false},
+	}
+	for _, tt := range tests {
+		if got := sampled(context.Background(), s, tt.name, tt.attrs...); got != tt.want {
+			t.Errorf("sampled(%q, %v) = %v, want %v", tt.name, tt.attrs, got, tt.want)
+		}
+	}
+}
+
+func TestNewSampler_ParentBasedRulesApplyToRoots(t *testing.T) {
+	s, _ := NewSampler("parentbased_always", []config.SamplerRule{{Name: "/health", Sampler: "never"}})
+	if sampled(context.Background(), s, "/health") {
+		t.Error("rule should apply to root spans")
+	}
+	if !sampled(remoteParent(true), s, "/health") {
+		t.Error("sampled parent should win over rules")
+	}
+}
+
+func TestNewSampler_Invalid(t *testing.T) {
+	if _, err := NewSampler("ratio:abc", nil); err == nil {
+		t.Error("expected error for invalid ratio")
+	}
+	if _, err := NewSampler("always", []config.SamplerRule{{Name: "x", Sampler: "maybe"}}); err == nil {
+		t.Error("expected error for invalid rule sampler")
+	}
+}
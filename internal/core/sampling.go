@@ -0,0 +1,270 @@
+package core
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+// SampledKey is the field added to an entry that follows suppressed entries
+// with the same sampling key. Its value is how many were suppressed.
+const SampledKey = "sampled"
+
+// sampleBuckets is the number of counters per level. Keys are hashed into
+// buckets, so memory is fixed regardless of how many distinct keys are seen;
+// colliding keys share a budget.
+const sampleBuckets = 4096
+
+// numLevels covers zapcore.DebugLevel through zapcore.FatalLevel.
+const numLevels = int(zapcore.FatalLevel-zapcore.DebugLevel) + 1
+
+type sampleCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// incCheckReset increments the counter, starting a new interval if the
+// current one has elapsed. Returns the count within the interval.
+func (c *sampleCounter) incCheckReset(now int64, interval time.Duration) uint64 {
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return c.count.Add(1)
+	}
+	c.count.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, now+interval.Nanoseconds()) {
+		// Another goroutine started the interval first.
+		return c.count.Add(1)
+	}
+	return 1
+}
+
+// sampleRule is the budget for one level. Levels without a rule are not sampled.
+type sampleRule struct {
+	initial    uint64
+	thereafter uint64
+	counters   *[sampleBuckets]sampleCounter
+	dropped    atomic.Uint64 // since the last summary
+}
+
+// sampler is the state shared by a root samplingCore and all of its With() children.
+type sampler struct {
+	root      zapcore.Core // receives summaries, without any With() fields
+	interval  time.Duration
+	keyFields map[string]bool
+	rules     [numLevels]*sampleRule
+
+	summaryInterval time.Duration
+	nextSummary     atomic.Int64
+}
+
+// samplingCore drops repeated entries: per interval, the first Initial entries
+// with the same key are written, then every Thereafter-th. The key is the
+// message plus the values of the configured key fields.
+type samplingCore struct {
+	zapcore.Core
+	s      *sampler
+	prefix uint64 // hash of key fields attached via With()
+}
+
+// NewSamplingCore wraps core with log sampling. If sampling is disabled the
+// core is returned unchanged.
+func NewSamplingCore(core zapcore.Core, cfg config.SamplingConfig) zapcore.Core {
+	if !cfg.Enabled {
+		return core
+	}
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	s := &sampler{
+		root:            core,
+		interval:        interval,
+		keyFields:       make(map[string]bool, len(cfg.KeyFields)),
+		summaryInterval: cfg.SummaryInterval,
+	}
+	for _, k := range cfg.KeyFields {
+		s.keyFields[k] = true
+	}
+	overrides := make(map[zapcore.Level]config.SamplingRule, len(cfg.Levels))
+	for name, r := range cfg.Levels {
+		overrides[parseLevel(name)] = r
+	}
+	for lvl := zapcore.DebugLevel; lvl <= zapcore.FatalLevel; lvl++ {
+		if lvl >= zapcore.ErrorLevel && !cfg.SampleErrors {
+			continue // Error and Critical are never sampled unless asked
+		}
+		initial, thereafter := cfg.Initial, cfg.Thereafter
+		if r, ok := overrides[lvl]; ok {
+			initial, thereafter = r.Initial, r.Thereafter
+		}
+		if initial <= 0 && thereafter <= 0 {
+			continue // no budget configured: not sampled
+		}
+		s.rules[lvl-zapcore.DebugLevel] = &sampleRule{
+			initial:    uint64(max(initial, 0)),
+			thereafter: uint64(max(thereafter, 0)),
+			counters:   new([sampleBuckets]sampleCounter),
+		}
+	}
+	if s.summaryInterval > 0 {
+		s.nextSummary.Store(time.Now().Add(s.summaryInterval).UnixNano())
+	}
+	return &samplingCore{Core: core, s: s, prefix: fnvOffset}
+}
+
+// fnvOffset is the FNV-1a 64-bit offset basis.
+const fnvOffset = 14695981039346656037
+
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplingCore{
+		Core:   c.Core.With(fields),
+		s:      c.s,
+		prefix: c.s.hashFields(c.prefix, fields),
+	}
+}
+
+func (c *samplingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	return ce.AddCore(ent, c)
+}
+
+// Write decides whether to keep the entry. Entries are forwarded through the
+// wrapped core's Check so per-output level gates still apply.
+func (c *samplingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	err := c.s.maybeSummarize(ent.Time)
+
+	rule := c.s.rule(ent.Level)
+	if rule == nil {
+		return errors.Join(err, writeThrough(c.Core, ent, fields))
+	}
+
+	h := hashString(c.prefix, ent.Message)
+	h = c.s.hashFields(h, fields)
+	counter := &rule.counters[h%sampleBuckets]
+
+	n := counter.incCheckReset(ent.Time.UnixNano(), c.s.interval)
+	if n > rule.initial && (rule.thereafter == 0 || (n-rule.initial)%rule.thereafter != 0) {
+		counter.dropped.Add(1)
+		rule.dropped.Add(1)
+		return err
+	}
+	if dropped := counter.dropped.Swap(0); dropped > 0 {
+		fields = append(fields[:len(fields):len(fields)], zap.Uint64(SampledKey, dropped))
+	}
+	return errors.Join(err, writeThrough(c.Core, ent, fields))
+}
+
+func (s *sampler) rule(lvl zapcore.Level) *sampleRule {
+	i := int(lvl - zapcore.DebugLevel)
+	if i < 0 || i >= numLevels {
+		return nil
+	}
+	return s.rules[i]
+}
+
+// maybeSummarize writes one Info entry with the per-level suppressed counts
+// once per SummaryInterval. It runs lazily on the logging path, so no summary
+// is written while nothing is logged. Returns the summary's write error.
+func (s *sampler) maybeSummarize(now time.Time) error {
+	if s.summaryInterval <= 0 {
+		return nil
+	}
+	next := s.nextSummary.Load()
+	if now.UnixNano() < next || !s.nextSummary.CompareAndSwap(next, now.Add(s.summaryInterval).UnixNano()) {
+		return nil
+	}
+
+	var fields []zapcore.Field
+	var total uint64
+	for i, r := range s.rules {
+		if r == nil {
+			continue
+		}
+		if n := r.dropped.Swap(0); n > 0 {
+			fields = append(fields, zap.Uint64((zapcore.DebugLevel+zapcore.Level(i)).String(), n))
+			total += n
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	fields = append(fields, zap.Uint64(SampledKey, total), zap.Duration("interval", s.summaryInterval))
+	ent := zapcore.Entry{Level: zapcore.InfoLevel, Time: now, LoggerName: "ion", Message: "log entries suppressed by sampling"}
+	return writeThrough(s.root, ent, fields)
+}
+
+// hashFields folds the values of configured key fields into h.
+func (s *sampler) hashFields(h uint64, fields []zapcore.Field) uint64 {
+	if len(s.keyFields) == 0 {
+		return h
+	}
+	for _, f := range fields {
+		if !s.keyFields[f.Key] {
+			continue
+		}
+		h = hashString(h, f.Key)
+		switch {
+		case f.Type == zapcore.StringType:
+			h = hashString(h, f.String)
+		case f.Interface != nil:
+			h = hashString(h, fieldString(f))
+		default:
+			h = hashString(h, strconv.FormatInt(f.Integer, 10))
+		}
+	}
+	return h
+}
+
+// hashString folds s into an FNV-1a hash without allocating.
+func hashString(h uint64, s string) uint64 {
+	const prime = 1099511628211
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime
+	}
+	// Separator so ("ab","c") and ("a","bc") differ.
+	h ^= 0xff
+	h *= prime
+	return h
+}
+
+// writeThrough writes an entry via core.Check so the per-output level gates,
+// which live in Check, are honoured. Write errors are returned so the
+// caller's CheckedEntry reports them to the logger's ErrorOutput.
+func writeThrough(core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) error {
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+	var errs writeErrors
+	ce.ErrorOutput = &errs
+	ce.Write(fields...)
+	return errs.err
+}
+
+// writeErrors captures the write error a CheckedEntry reports to its
+// ErrorOutput as "<time> write error: <err>".
+type writeErrors struct {
+	err error
+}
+
+func (w *writeErrors) Write(p []byte) (int, error) {
+	msg := strings.TrimSpace(string(p))
+	if _, after, ok := strings.Cut(msg, " write error: "); ok {
+		msg = after
+	}
+	w.err = errors.Join(w.err, errors.New(msg))
+	return len(p), nil
+}
+
+func (w *writeErrors) Sync() error { return nil }
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+func newSampledLogger(cfg config.SamplingConfig) (*zap.Logger, *observer.ObservedLogs) {
+	obs, logs := observer.New(zapcore.DebugLevel)
+	cfg.Enabled = true
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Minute
+	}
+	return zap.New(NewSamplingCore(obs, cfg)), logs
+}
+
+func TestSamplingCore_InitialThereafter(t *testing.T) {
+	logger, logs := newSampledLogger(config.SamplingConfig{Initial: 2, Thereafter: 3})
+
+	for i := 0; i < 10; i++ {
+		logger.Info("tx received")
+	}
+
+	// Entries 1, 2 (initial), then 5 and 8 (every 3rd after initial).
+	entries := logs.All()
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(entries))
+	}
+	if _, ok := entries[1].ContextMap()[SampledKey]; ok {
+		t.Error("entries within the initial budget should not carry a sampled count")
+	}
+	if got := entries[2].ContextMap()[SampledKey]; got != uint64(2) {
+		t.Errorf("%s = %v, want 2 suppressed", SampledKey, got)
+	}
+}
+
+func TestSamplingCore_ErrorsExempt(t *testing.T) {
+	logger, logs := newSampledLogger(config.SamplingConfig{Initial: 1, Thereafter: 0})
+
+	for i := 0; i < 5; i++ {
+		logger.Error("disk failure")
+		logger.Warn("slow peer")
+	}
+
+	if got := logs.FilterMessage("disk failure").Len(); got != 5 {
+		t.Errorf("error entries = %d, want 5 (exempt)", got)
+	}
+	if got := logs.FilterMessage("slow peer").Len(); got != 1 {
+		t.Errorf("warn entries = %d, want 1", got)
+	}
+
+	logger, logs = newSampledLogger(config.SamplingConfig{Initial: 1, SampleErrors: true})
+	for i := 0; i < 5; i++ {
+		logger.Error("disk failure")
+	}
+	if got := logs.Len(); got != 1 {
+		t.Errorf("with SampleErrors, error entries = %d, want 1", got)
+	}
+}
+
+func TestSamplingCore_KeyFields(t *testing.T) {
+	logger, logs := newSampledLogger(config.SamplingConfig{Initial: 1, KeyFields: []string{"peer_id"}})
+
+	for i := 0; i < 3; i++ {
+		logger.Info("tx received", zap.String("peer_id", "a"))
+		logger.Info("tx received", zap.String("peer_id", "b"))
+		logger.With(zap.String("peer_id", "c")).Info("tx received")
+		logger.Info("tx received", zap.String("tx", "ignored-key"))
+	}
+
+	// One per peer, plus one for entries without a peer_id.
+	if got := logs.Len(); got != 4 {
+		t.Errorf("got %d entries, want 4", got)
+	}
+}
+
+func TestSamplingCore_LevelOverride(t *testing.T) {
+	logger, logs := newSampledLogger(config.SamplingConfig{
+		Initial: 1,
+		Levels:  map[string]config.SamplingRule{"DEBUG": {Initial: 0, Thereafter: 0}},
+	})
+
+	for i := 0; i < 3; i++ {
+		logger.Debug("verbose")
+		logger.Info("normal")
+	}
+	if got := logs.FilterMessage("verbose").Len(); got != 3 {
+		t.Errorf("debug entries = %d, want 3 (level not sampled)", got)
+	}
+	if got := logs.FilterMessage("normal").Len(); got != 1 {
+		t.Errorf("info entries = %d, want 1", got)
+	}
+}
+
+func TestSamplingCore_Summary(t *testing.T) {
+	logger, logs := newSampledLogger(config.SamplingConfig{Initial: 1, SummaryInterval: time.Nanosecond})
+
+	logger.Info("spam")
+	logger.Info("spam")
+	time.Sleep(time.Millisecond)
+	logger.Info("other")
+
+	summaries := logs.FilterMessage("log entries suppressed by sampling").All()
+	if len(summaries) != 1 {
+		t.Fatalf("got %d summaries, want 1", len(summaries))
+	}
+	fields := summaries[0].ContextMap()
+	if fields[SampledKey] != uint64(1) || fields["info"] != uint64(1) {
+		t.Errorf("summary fields = %v, want 1 info suppressed", fields)
+	}
+}
+
+func TestNewSamplingCore_Disabled(t *testing.T) {
+	obs, _ := observer.New(zapcore.DebugLevel)
+	if got := NewSamplingCore(obs, config.SamplingConfig{Initial: 1}); got != obs {
+		t.Error("disabled sampling should return the core unchanged")
+	}
+}
+
+// failingCore rejects every write, like a full disk.
+type failingCore struct{ zapcore.Core }
+
+func (failingCore) Enabled(zapcore.Level) bool { return true }
+
+func (c failingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+func (failingCore) Write(zapcore.Entry, []zapcore.Field) error {
+	return errors.New("no space left on device")
+}
+
+func TestSamplingCore_ReportsWriteErrors(t *testing.T) {
+	var errOut bytes.Buffer
+	core := NewSamplingCore(failingCore{zapcore.NewNopCore()}, config.SamplingConfig{Enabled: true, Interval: time.Minute, Initial: 1})
+	logger := zap.New(core, zap.ErrorOutput(zapcore.AddSync(&errOut)))
+
+	logger.Info("tx received")
+	logger.Error("disk failure") // errors are exempt from sampling
+	if got := strings.Count(errOut.String(), "write error: no space left on device"); got != 2 {
+		t.Errorf("ErrorOutput = %q, want both write errors reported", errOut.String())
+	}
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+// SpanEvents records log entries as events on the span active in the
+// entry's context, so logs show up on the trace they were written in.
+type SpanEvents struct {
+	min       zapcore.Level
+	setStatus bool
+	redactor  *Redactor // applied as for the "otel" output
+}
+
+// NewSpanEvents builds a SpanEvents from cfg. Returns nil if disabled.
+// If redactor is non-nil, fields and error messages are redacted first.
+func NewSpanEvents(cfg config.LogsAsEventsConfig, redactor *Redactor) *SpanEvents {
+	if !cfg.Enabled {
+		return nil
+	}
+	min := zapcore.WarnLevel
+	if cfg.Level != "" {
+		min = parseLevel(cfg.Level)
+	}
+	return &SpanEvents{min: min, setStatus: cfg.SetErrorStatus, redactor: redactor}
+}
+
+// Enabled reports whether entries at lvl are recorded. Safe on nil.
+func (s *SpanEvents) Enabled(lvl zapcore.Level) bool {
+	return s != nil && lvl >= s.min
+}
+
+// Record adds the entry to the recording span in ctx, if any.
+// Entries at Error and above with a non-nil err use RecordError; others AddEvent.
+func (s *SpanEvents) Record(ctx context.Context, lvl zapcore.Level, logger, msg string, err error, fields []zapcore.Field) {
+	if !s.Enabled(lvl) || ctx == nil {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	if s.redactor != nil {
+		fields = s.redactor.Redact(fields)
+		if err != nil {
+			err = s.redactError(err)
+		}
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(fields)+3)
+	attrs = append(attrs, attribute.String("log.severity", lvl.String()))
+	if logger != "" {
+		attrs = append(attrs, attribute.String("log.logger", logger))
+	}
+	attrs = AppendAttributes(attrs, fields)
+
+	if err != nil && lvl >= zapcore.ErrorLevel {
+		attrs = append(attrs, attribute.String("log.message", msg))
+		span.RecordError(err, trace.WithAttributes(attrs...))
+	} else {
+		span.AddEvent(msg, trace.WithAttributes(attrs...))
+	}
+
+	if s.setStatus && lvl >= zapcore.ErrorLevel {
+		span.SetStatus(codes.Error, msg)
+	}
+}
+
+// redactError applies the redactor to the error text. Returns nil if the
+// "error" key is dropped.
+func (s *SpanEvents) redactError(err error) error {
+	out := s.redactor.Redact([]zapcore.Field{zap.Error(err)})
+	switch {
+	case len(out) == 0:
+		return nil
+	case out[0].Type == zapcore.StringType:
+		return errors.New(out[0].String)
+	default:
+		return err
+	}
+}
+
+// AppendAttributes converts zap fields to span attributes. Internal carrier
+// fields and namespaces are skipped; types without an attribute equivalent are formatted
+// as strings.
+func AppendAttributes(attrs []attribute.KeyValue, fields []zapcore.Field) []attribute.KeyValue {
+	for _, f := range fields {
+		if f.Key == SentinelKey {
+			continue
+		}
+		switch f.Type {
+		case zapcore.StringType:
+			attrs = append(attrs, attribute.String(f.Key, f.String))
+		case zapcore.BoolType:
+			attrs = append(attrs, attribute.Bool(f.Key, f.Integer == 1))
+		case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type,
+			zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+			attrs = append(attrs, attribute.Int64(f.Key, f.Integer))
+		case zapcore.Uint64Type, zapcore.UintptrType:
+			u := uint64(f.Integer)
+			if u > math.MaxInt64 {
+				attrs = append(attrs, attribute.String(f.Key, fmt.Sprint(u)))
+			} else {
+				attrs = append(attrs, attribute.Int64(f.Key, int64(u)))
+			}
+		case zapcore.Float64Type:
+			attrs = append(attrs, attribute.Float64(f.Key, math.Float64frombits(uint64(f.Integer))))
+		case zapcore.Float32Type:
+			attrs = append(attrs, attribute.Float64(f.Key, float64(math.Float32frombits(uint32(f.Integer)))))
+		case zapcore.DurationType:
+			attrs = append(attrs, attribute.String(f.Key, time.Duration(f.Integer).String()))
+		case zapcore.SkipType, zapcore.NamespaceType:
+		default:
+			attrs = append(attrs, attribute.String(f.Key, fieldString(f)))
+		}
+	}
+	return attrs
+}
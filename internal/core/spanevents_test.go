@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+func recordOnSpan(t *testing.T, se *SpanEvents, record func(ctx context.Context)) sdktrace.ReadOnlySpan {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "apply-block")
+	record(ctx)
+	span.End()
+	return rec.Ended()[0]
+}
+
+func eventAttr(attrs []attribute.KeyValue, key string) (string, bool) {
+	for _, kv := range attrs {
+		if string(kv.Key) == key {
+			return kv.Value.Emit(), true
+		}
+	}
+	return "", false
+}
+
+func TestSpanEvents_Levels(t *testing.T) {
+	se := NewSpanEvents(config.LogsAsEventsConfig{Enabled: true, Level: "warn"}, nil)
+	span := recordOnSpan(t, se, func(ctx context.Context) {
+		se.Record(ctx, zapcore.InfoLevel, "", "block applied", nil, nil)
+		se.Record(ctx, zapcore.WarnLevel, "p2p", "peer slow", nil, []zapcore.Field{
+			zap.String("peer", "node-7"), zap.Int("height", 42), zap.Bool("retry", true),
+		})
+	})
+
+	events := span.Events()
+	if len(events) != 1 {
+		t.Fatalf("recorded %d events, want 1 (info is below warn)", len(events))
+	}
+	ev := events[0]
+	if ev.Name != "peer slow" {
+		t.Errorf("event name = %q, want %q", ev.Name, "peer slow")
+	}
+	tests := map[string]string{"log.severity": "warn", "log.logger": "p2p", "peer": "node-7", "height": "42", "retry": "true"}
+	for key, want := range tests {
+		if got, _ := eventAttr(ev.Attributes, key); got != want {
+			t.Errorf("attribute %s = %q, want %q", key, got, want)
+		}
+	}
+	if span.Status().Code == codes.Error {
+		t.Error("warn entry set span status to Error")
+	}
+}
+
+func TestSpanEvents_ErrorStatus(t *testing.T) {
+	se := NewSpanEvents(config.LogsAsEventsConfig{Enabled: true, Level: "error", SetErrorStatus: true}, nil)
+	span := recordOnSpan(t, se, func(ctx context.Context) {
+		se.Record(ctx, zapcore.ErrorLevel, "", "state root mismatch", errors.New("root 0xab != 0xcd"), nil)
+	})
+
+	events := span.Events()
+	if len(events) != 1 || events[0].Name != "exception" {
+		t.Fatalf("events = %+v, want one exception event", events)
+	}
+	if got, _ := eventAttr(events[0].Attributes, "exception.message"); got != "root 0xab != 0xcd" {
+		t.Errorf("exception.message = %q, want the error text", got)
+	}
+	if got, _ := eventAttr(events[0].Attributes, "log.message"); got != "state root mismatch" {
+		t.Errorf("log.message = %q, want the log message", got)
+	}
+	if st := span.Status(); st.Code != codes.Error || st.Description != "state root mismatch" {
+		t.Errorf("status = %+v, want Error with the log message", st)
+	}
+}
+
+func TestSpanEvents_Redaction(t *testing.T) {
+	r, err := NewRedactor(config.RedactionConfig{
+		Enabled:   true,
+		Keys:      map[string]string{"password": RedactDrop},
+		Detectors: []string{"email"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	se := NewSpanEvents(config.LogsAsEventsConfig{Enabled: true, Level: "warn"}, r)
+	span := recordOnSpan(t, se, func(ctx context.Context) {
+		se.Record(ctx, zapcore.ErrorLevel, "", "login failed", errors.New("no account for alice@example.com"), []zapcore.Field{
+			zap.String("password", "hunter2"),
+		})
+	})
+
+	attrs := span.Events()[0].Attributes
+	if _, ok := eventAttr(attrs, "password"); ok {
+		t.Error("dropped key reached the span event")
+	}
+	if got, _ := eventAttr(attrs, "exception.message"); got != "no account for [REDACTED:email]" {
+		t.Errorf("exception.message = %q, want redacted", got)
+	}
+}
+
+func TestSpanEvents_NoRecordingSpan(t *testing.T) {
+	var disabled *SpanEvents
+	if disabled.Enabled(zapcore.FatalLevel) {
+		t.Error("nil SpanEvents reports enabled")
+	}
+	if NewSpanEvents(config.LogsAsEventsConfig{}, nil) != nil {
+		t.Error("NewSpanEvents() with Enabled=false should return nil")
+	}
+
+	se := NewSpanEvents(config.LogsAsEventsConfig{Enabled: true}, nil)
+	se.Record(context.Background(), zapcore.ErrorLevel, "", "no span", errors.New("x"), nil) // must not panic
+}
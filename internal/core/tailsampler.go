@@ -0,0 +1,304 @@
+package core
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+// TailSamplingStats reports the tail sampler's decisions and drops.
+type TailSamplingStats struct {
+	TracesKept    uint64 `json:"traces_kept"`
+	TracesDropped uint64 `json:"traces_dropped"` // sampled out by ratio
+	TracesEvicted uint64 `json:"traces_evicted"` // decided early because MaxTraces was reached
+	SpansDropped  uint64 `json:"spans_dropped"`  // spans of sampled-out traces, including late arrivals
+	SpansOverflow uint64 `json:"spans_overflow"` // spans dropped by MaxSpansPerTrace
+	Buffered      int    `json:"buffered"`       // traces currently buffered
+}
+
+// TailSampler is a SpanProcessor that buffers the spans of each local trace
+// and decides once the trace's local root ends or its window expires:
+// traces with an error span or a span slower than the latency threshold are
+// always kept; the rest are kept at the configured ratio. Kept spans are
+// forwarded to the next processor (usually the batcher).
+//
+// Memory is bounded by MaxTraces and MaxSpansPerTrace. Spans ending after
+// their trace was decided follow that decision.
+type TailSampler struct {
+	next      sdktrace.SpanProcessor
+	window    time.Duration
+	latency   time.Duration
+	threshold uint64 // trace ID ratio threshold, as in TraceIDRatioBased
+	maxTraces int
+	maxSpans  int
+
+	mu      sync.Mutex
+	traces  map[trace.TraceID]*tailTrace
+	order   *list.List // of trace.TraceID, oldest first
+	decided *decisionCache
+
+	tracesKept    atomic.Uint64
+	tracesDropped atomic.Uint64
+	tracesEvicted atomic.Uint64
+	spansDropped  atomic.Uint64
+	spansOverflow atomic.Uint64
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+type tailTrace struct {
+	spans     []sdktrace.ReadOnlySpan
+	keep      bool // an error or slow span was seen
+	firstSeen time.Time
+	elem      *list.Element
+}
+
+// NewTailSampler wraps next with tail-based sampling.
+func NewTailSampler(next sdktrace.SpanProcessor, cfg config.TailSamplingConfig) *TailSampler {
+	window := cfg.Window
+	if window <= 0 {
+		window = 10 * time.Second
+	}
+	maxTraces := cfg.MaxTraces
+	if maxTraces <= 0 {
+		maxTraces = 10000
+	}
+	maxSpans := cfg.MaxSpansPerTrace
+	if maxSpans <= 0 {
+		maxSpans = 1000
+	}
+
+	ts := &TailSampler{
+		next:      next,
+		window:    window,
+		latency:   cfg.LatencyThreshold,
+		threshold: ratioThreshold(cfg.Ratio),
+		maxTraces: maxTraces,
+		maxSpans:  maxSpans,
+		traces:    make(map[trace.TraceID]*tailTrace),
+		order:     list.New(),
+		decided:   newDecisionCache(maxTraces),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go ts.expireLoop()
+	return ts
+}
+
+// ratioThreshold converts a ratio to a threshold on the low 63 bits of the trace ID.
+func ratioThreshold(ratio float64) uint64 {
+	switch {
+	case ratio >= 1:
+		return 1 << 63
+	case ratio <= 0:
+		return 0
+	default:
+		return uint64(ratio * (1 << 63))
+	}
+}
+
+// OnStart forwards to the next processor.
+func (ts *TailSampler) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	ts.next.OnStart(parent, s)
+}
+
+// OnEnd buffers the span, deciding the trace if this is its local root.
+func (ts *TailSampler) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+	tid := s.SpanContext().TraceID()
+
+	ts.mu.Lock()
+	if keep, ok := ts.decided.get(tid); ok {
+		ts.mu.Unlock()
+		if keep {
+			ts.next.OnEnd(s)
+		} else {
+			ts.spansDropped.Add(1)
+		}
+		return
+	}
+
+	var flush []*tailTrace
+	t, ok := ts.traces[tid]
+	if !ok {
+		if len(ts.traces) >= ts.maxTraces {
+			// Full: decide the oldest trace now rather than grow.
+			oldest := ts.order.Front().Value.(trace.TraceID)
+			flush = append(flush, ts.decideLocked(oldest))
+			ts.tracesEvicted.Add(1)
+		}
+		t = &tailTrace{firstSeen: time.Now()}
+		t.elem = ts.order.PushBack(tid)
+		ts.traces[tid] = t
+	}
+
+	if s.Status().Code == codes.Error || (ts.latency > 0 && s.EndTime().Sub(s.StartTime()) >= ts.latency) {
+		t.keep = true
+	}
+	if len(t.spans) < ts.maxSpans {
+		t.spans = append(t.spans, s)
+	} else {
+		ts.spansOverflow.Add(1)
+	}
+
+	// The local root ends last in a well-formed trace: decide now.
+	if !s.Parent().IsValid() || s.Parent().IsRemote() {
+		flush = append(flush, ts.decideLocked(tid))
+	}
+	ts.mu.Unlock()
+
+	ts.forward(flush)
+}
+
+// decideLocked removes the trace from the buffer and records the decision
+// in t.keep. The caller must hold ts.mu and forward the result.
+func (ts *TailSampler) decideLocked(tid trace.TraceID) *tailTrace {
+	t := ts.traces[tid]
+	delete(ts.traces, tid)
+	ts.order.Remove(t.elem)
+
+	if !t.keep {
+		t.keep = binary.BigEndian.Uint64(tid[8:16])>>1 < ts.threshold
+	}
+	ts.decided.put(tid, t.keep)
+	return t
+}
+
+// forward sends kept traces to the next processor and counts dropped ones.
+func (ts *TailSampler) forward(traces []*tailTrace) {
+	for _, t := range traces {
+		if !t.keep {
+			ts.tracesDropped.Add(1)
+			ts.spansDropped.Add(uint64(len(t.spans)))
+			continue
+		}
+		ts.tracesKept.Add(1)
+		for _, s := range t.spans {
+			ts.next.OnEnd(s)
+		}
+	}
+}
+
+// expireLoop decides traces whose window has elapsed (e.g. a local root
+// that never ends, or a trace made only of spans with remote parents).
+func (ts *TailSampler) expireLoop() {
+	defer close(ts.done)
+	tick := ts.window / 4
+	if tick < 10*time.Millisecond {
+		tick = 10 * time.Millisecond
+	}
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ts.stop:
+			return
+		case now := <-ticker.C:
+			ts.forward(ts.expire(now))
+		}
+	}
+}
+
+func (ts *TailSampler) expire(now time.Time) []*tailTrace {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	var flush []*tailTrace
+	for e := ts.order.Front(); e != nil; {
+		tid := e.Value.(trace.TraceID)
+		e = e.Next()
+		if now.Sub(ts.traces[tid].firstSeen) < ts.window {
+			break // order is oldest first
+		}
+		flush = append(flush, ts.decideLocked(tid))
+	}
+	return flush
+}
+
+// flushAll decides every buffered trace.
+func (ts *TailSampler) flushAll() {
+	ts.mu.Lock()
+	flush := make([]*tailTrace, 0, len(ts.traces))
+	for e := ts.order.Front(); e != nil; {
+		tid := e.Value.(trace.TraceID)
+		e = e.Next()
+		flush = append(flush, ts.decideLocked(tid))
+	}
+	ts.mu.Unlock()
+	ts.forward(flush)
+}
+
+// ForceFlush decides all buffered traces and flushes the next processor.
+func (ts *TailSampler) ForceFlush(ctx context.Context) error {
+	ts.flushAll()
+	return ts.next.ForceFlush(ctx)
+}
+
+// Shutdown stops the expiry loop, decides all buffered traces, and shuts
+// down the next processor.
+func (ts *TailSampler) Shutdown(ctx context.Context) error {
+	ts.stopOnce.Do(func() {
+		close(ts.stop)
+		<-ts.done
+	})
+	ts.flushAll()
+	return ts.next.Shutdown(ctx)
+}
+
+// Stats returns the current counters. Safe on a nil *TailSampler.
+func (ts *TailSampler) Stats() TailSamplingStats {
+	if ts == nil {
+		return TailSamplingStats{}
+	}
+	ts.mu.Lock()
+	buffered := len(ts.traces)
+	ts.mu.Unlock()
+	return TailSamplingStats{
+		TracesKept:    ts.tracesKept.Load(),
+		TracesDropped: ts.tracesDropped.Load(),
+		TracesEvicted: ts.tracesEvicted.Load(),
+		SpansDropped:  ts.spansDropped.Load(),
+		SpansOverflow: ts.spansOverflow.Load(),
+		Buffered:      buffered,
+	}
+}
+
+// decisionCache remembers recent decisions so late spans follow them.
+// It holds at most size entries, forgetting the oldest first.
+type decisionCache struct {
+	keep map[trace.TraceID]bool
+	ring []trace.TraceID
+	next int
+}
+
+func newDecisionCache(size int) *decisionCache {
+	return &decisionCache{keep: make(map[trace.TraceID]bool, size), ring: make([]trace.TraceID, size)}
+}
+
+func (c *decisionCache) get(tid trace.TraceID) (keep, ok bool) {
+	keep, ok = c.keep[tid]
+	return keep, ok
+}
+
+func (c *decisionCache) put(tid trace.TraceID, keep bool) {
+	if _, ok := c.keep[tid]; !ok {
+		if old := c.ring[c.next]; old.IsValid() {
+			delete(c.keep, old)
+		}
+		c.ring[c.next] = tid
+		c.next = (c.next + 1) % len(c.ring)
+	}
+	c.keep[tid] = keep
+}
@@ -0,0 +1,191 @@
+package core
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/JupiterMetaLabs/ion/internal/config"
+)
+
+func newTailTracer(t *testing.T, cfg config.TailSamplingConfig) (trace.Tracer, *TailSampler, *tracetest.SpanRecorder) {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	ts := NewTailSampler(rec, cfg)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(ts))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	return tp.Tracer("test"), ts, rec
+}
+
+// runTrace creates a root span with one child and applies mark to the child.
+func runTrace(tracer trace.Tracer, mark func(trace.Span)) {
+	ctx, root := tracer.Start(context.Background(), "validate-block")
+	_, child := tracer.Start(ctx, "verify-signatures")
+	mark(child)
+	child.End()
+	root.End()
+}
+
+func TestTailSampler_KeepsErrorsAndSlowTraces(t *testing.T) {
+	tracer, ts, rec := newTailTracer(t, config.TailSamplingConfig{Ratio: 0, LatencyThreshold: 20 * time.Millisecond})
+
+	runTrace(tracer, func(trace.Span) {})
+	runTrace(tracer, func(s trace.Span) { s.SetStatus(codes.Error, "bad signature") })
+	runTrace(tracer, func(trace.Span) { time.Sleep(25 * time.Millisecond) })
+
+	if got := len(rec.Ended()); got != 4 {
+		t.Errorf("exported %d spans, want 4 (error and slow traces)", got)
+	}
+	stats := ts.Stats()
+	if stats.TracesKept != 2 || stats.TracesDropped != 1 || stats.SpansDropped != 2 {
+		t.Errorf("stats = %+v, want 2 kept, 1 dropped, 2 spans dropped", stats)
+	}
+	if stats.Buffered != 0 {
+		t.Errorf("buffered = %d, want 0 after local roots ended", stats.Buffered)
+	}
+}
+
+func TestTailSampler_Ratio(t *testing.T) {
+	tracer, ts, rec := newTailTracer(t, config.TailSamplingConfig{Ratio: 1})
+	for i := 0; i < 5; i++ {
+		runTrace(tracer, func(trace.Span) {})
+	}
+	if got := len(rec.Ended()); got != 10 {
+		t.Errorf("ratio 1 exported %d spans, want 10", got)
+	}
+	if got := ts.Stats().TracesKept; got != 5 {
+		t.Errorf("traces kept = %d, want 5", got)
+	}
+}
+
+func TestTailSampler_LateSpansFollowDecision(t *testing.T) {
+	tracer, ts, rec := newTailTracer(t, config.TailSamplingConfig{Ratio: 0})
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, late := tracer.Start(ctx, "late")
+	root.SetStatus(codes.Error, "failed")
+	root.End()
+	late.End() // ends after the trace was decided
+
+	if got := len(rec.Ended()); got != 2 {
+		t.Errorf("exported %d spans, want 2 (late span follows keep)", got)
+	}
+
+	ctx, root = tracer.Start(context.Background(), "root")
+	_, late = tracer.Start(ctx, "late")
+	root.End()
+	late.End()
+	if got := ts.Stats().SpansDropped; got != 2 {
+		t.Errorf("spans dropped = %d, want 2 (late span follows drop)", got)
+	}
+}
+
+func TestTailSampler_MemoryCaps(t *testing.T) {
+	tracer, ts, rec := newTailTracer(t, config.TailSamplingConfig{Ratio: 1, MaxTraces: 2, MaxSpansPerTrace: 2, Window: time.Hour})
+
+	// Three open traces with a cap of two: the oldest is decided early.
+	var roots []trace.Span
+	for i := 0; i < 3; i++ {
+		ctx, root := tracer.Start(context.Background(), "root")
+		for j := 0; j < 3; j++ {
+			_, child := tracer.Start(ctx, "child")
+			child.End()
+		}
+		roots = append(roots, root)
+	}
+
+	stats := ts.Stats()
+	if stats.TracesEvicted != 1 || stats.Buffered != 2 {
+		t.Errorf("stats = %+v, want 1 evicted, 2 buffered", stats)
+	}
+	if stats.SpansOverflow != 3 {
+		t.Errorf("spans overflow = %d, want 3 (one per trace over MaxSpansPerTrace)", stats.SpansOverflow)
+	}
+	if got := len(rec.Ended()); got != 2 {
+		t.Errorf("exported %d spans, want 2 from the evicted trace", got)
+	}
+	for _, r := range roots {
+		r.End()
+	}
+}
+
+func TestTailSampler_WindowExpiry(t *testing.T) {
+	tracer, ts, rec := newTailTracer(t, config.TailSamplingConfig{Ratio: 1, Window: 20 * time.Millisecond})
+
+	ctx, root := tracer.Start(context.Background(), "never-ends")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	defer root.End()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(rec.Ended()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := len(rec.Ended()); got != 1 {
+		t.Errorf("exported %d spans after window, want 1", got)
+	}
+	if got := ts.Stats().Buffered; got != 0 {
+		t.Errorf("buffered = %d, want 0", got)
+	}
+}
+
+func TestTailSampler_ShutdownFlushes(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	ts := NewTailSampler(rec, config.TailSamplingConfig{Ratio: 1, Window: time.Hour})
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(ts))
+
+	tracer := tp.Tracer("test")
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error: %v", err)
+	}
+	if got := len(rec.Ended()); got != 1 {
+		t.Errorf("exported %d spans on shutdown, want 1", got)
+	}
+	root.End()
+}
+
+func TestSetupTracerProvider_TailSamplingKeepsHeadSampler(t *testing.T) {
+	cfg := config.Default().Tracing
+	cfg.Enabled, cfg.Protocol, cfg.File.Path = true, "file", filepath.Join(t.TempDir(), "traces.jsonl")
+	cfg.TailSampling.Enabled = true
+	cfg.Sampler = "parentbased_always"
+	cfg.SamplerRules = []config.SamplerRule{{Name: "/health", Sampler: "never"}}
+	rec := tracetest.NewSpanRecorder()
+	tp, err := SetupTracerProvider(cfg, nil, Components{SpanProcessors: []sdktrace.SpanProcessor{rec}})
+	if err != nil {
+		t.Fatalf("SetupTracerProvider() error: %v", err)
+	}
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+	tracer := tp.Tracer("test")
+
+	unsampled := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+		Remote:  true,
+	}))
+	for _, start := range []struct {
+		ctx  context.Context
+		name string
+	}{{unsampled, "/tx.Submit"}, {context.Background(), "/health"}} {
+		_, span := tracer.Start(start.ctx, start.name)
+		if span.SpanContext().IsSampled() {
+			t.Errorf("%s: span sampled, want the head sampler's decision kept", start.name)
+		}
+		span.End()
+	}
+	_, span := tracer.Start(context.Background(), "/tx.Submit")
+	span.End()
+	if got := rec.Ended(); len(got) != 1 || got[0].Name() != "/tx.Submit" {
+		t.Errorf("injected processor got %d spans, want only the head-sampled one", len(got))
+	}
+}
@@ -2,11 +2,16 @@ package ion
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"net/http"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/JupiterMetaLabs/ion/internal/core"
 )
@@ -49,7 +54,7 @@ var _ Logger = (*Ion)(nil)
 //	counter, _ := meter.Int64Counter("http.requests.total")
 //	counter.Add(ctx, 1)
 type Ion struct {
-	*zapLogger // Embedded: promotes Debug, Info, Warn, Error, Critical, Sync, SetLevel, GetLevel.
+	*zapLogger // Embedded: promotes Debug, Info, Warn, Error, Critical, Sync, SetLevel, GetLevel, SetLevelFor, LevelFor, SetOutputLevel.
 	// Caller depth is unified: all log calls are 1 frame above zap, matching AddCallerSkip(1).
 	serviceName    string
 	version        string
@@ -57,6 +62,8 @@ type Ion struct {
 	tracingEnabled bool
 	meterProvider  *core.MeterProvider
 	metricsEnabled bool
+	warnings       []Warning // init warnings from New, reported by AdminHandler
+	goroutines     *goroutineCounters
 }
 
 // Warning represents a non-fatal initialization issue.
@@ -79,35 +86,59 @@ func (w Warning) Error() string {
 //   - *Ion: Always returns a working Ion instance (may use fallbacks)
 //   - []Warning: Non-fatal issues (e.g., OTEL connection failed, tracing disabled)
 //   - error: Fatal configuration errors
-func New(cfg Config) (*Ion, []Warning, error) {
+//
+// Options add components Config cannot express, such as an extra zap core or
+// span processor (see [Option]).
+func New(cfg Config, opts ...Option) (*Ion, []Warning, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	o := &newOptions{}
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
 	var warnings []Warning
 
 	ion := &Ion{
 		serviceName: cfg.ServiceName,
 		version:     cfg.Version,
+		goroutines:  &goroutineCounters{},
+	}
+
+	// The OTEL resource is detected once and shared by logs, traces, and
+	// metrics, so every signal reports the same host and process.
+	res, err := core.NewResource(context.Background(), cfg.ServiceName, cfg.Version, nil)
+	if err != nil {
+		warnings = append(warnings, Warning{Component: "otel", Err: err})
 	}
 
 	// 1. Setup Logger (Zap + OTEL Logs)
-	zapRes, err := core.NewZapLogger(cfg)
+	zapRes, err := core.NewZapLogger(cfg, res, o.components)
 	if err != nil {
 		// Fatal error if we can't even init Zap (e.g. file error)
 		return nil, nil, fmt.Errorf("failed to init logger: %w", err)
 	}
 
+	extractors := o.extractors
+	if len(cfg.Tracing.BaggageToLogs) > 0 {
+		extractors = append([]ContextExtractor{baggageExtractor(cfg.Tracing.BaggageToLogs)}, extractors...)
+	}
+
 	// Construct the logger wrapper
 	ion.zapLogger = &zapLogger{
 		zap:          zapRes.Logger,
 		config:       cfg,
 		atomicLvl:    zapRes.AtomicLevel,
+		levels:       zapRes.Levels,
 		otelProvider: zapRes.OTELProvider,
+		spanEvents:   zapRes.SpanEvents,
+		extractors:   extractors,
 	}
 
 	// 2. Setup Tracing (OTEL Traces)
-	if cfg.Tracing.Enabled {
+	if cfg.Tracing.Enabled || len(o.components.SpanProcessors) > 0 || len(o.components.SpanExporters) > 0 {
 		// Use Tracing endpoint or fallback to OTEL endpoint
 		if cfg.Tracing.Endpoint == "" {
 			cfg.Tracing.Endpoint = cfg.OTEL.Endpoint
@@ -140,8 +171,13 @@ func New(cfg Config) (*Ion, []Warning, error) {
 				cfg.Tracing.Headers[k] = v
 			}
 		}
+		if !cfg.Tracing.Queue.Enabled {
+			cfg.Tracing.Queue = cfg.OTEL.Queue
+		}
+		// Resource attributes: OTEL.Attributes are shared, Tracing.Attributes win on conflict
+		cfg.Tracing.Attributes = mergeAttributes(cfg.OTEL.Attributes, cfg.Tracing.Attributes)
 
-		tp, err := core.SetupTracerProvider(cfg.Tracing, cfg.ServiceName, cfg.Version)
+		tp, err := core.SetupTracerProvider(cfg.Tracing, res, o.components)
 		if err != nil {
 			warnings = append(warnings, Warning{
 				Component: "tracing",
@@ -154,7 +190,7 @@ func New(cfg Config) (*Ion, []Warning, error) {
 	}
 
 	// 3. Setup Metrics (OTEL Metrics)
-	if cfg.Metrics.Enabled {
+	if cfg.Metrics.Enabled || len(o.components.MetricReaders) > 0 {
 		// Use Metrics endpoint or fallback to OTEL endpoint
 		if cfg.Metrics.Endpoint == "" {
 			cfg.Metrics.Endpoint = cfg.OTEL.Endpoint
@@ -179,8 +215,13 @@ func New(cfg Config) (*Ion, []Warning, error) {
 				cfg.Metrics.Headers[k] = v
 			}
 		}
+		if !cfg.Metrics.Queue.Enabled {
+			cfg.Metrics.Queue = cfg.OTEL.Queue
+		}
+		// Resource attributes: OTEL.Attributes are shared, Metrics.Attributes win on conflict
+		cfg.Metrics.Attributes = mergeAttributes(cfg.OTEL.Attributes, cfg.Metrics.Attributes)
 
-		mp, err := core.SetupMeterProvider(cfg.Metrics, cfg.ServiceName, cfg.Version)
+		mp, err := core.SetupMeterProvider(cfg.Metrics, res, o.components)
 		if err != nil {
 			warnings = append(warnings, Warning{
 				Component: "metrics",
@@ -192,9 +233,49 @@ func New(cfg Config) (*Ion, []Warning, error) {
 		}
 	}
 
+	// Export queue counters are reported through the instance's own metrics.
+	if ion.metricsEnabled {
+		queues := map[string]*core.ExportQueue{
+			"logs":    zapRes.OTELProvider.Queue(),
+			"traces":  ion.tracerProvider.Queue(),
+			"metrics": ion.meterProvider.Queue(),
+		}
+		if err := core.RegisterQueueMetrics(ion.meterProvider.Meter(core.InstrumentationName), queues); err != nil {
+			warnings = append(warnings, Warning{
+				Component: "metrics",
+				Err:       fmt.Errorf("failed to register export queue metrics: %w", err),
+			})
+		}
+	}
+
+	// 4. Install globals only on request, so several instances can share a process.
+	if cfg.SetGlobalProviders {
+		ion.tracerProvider.SetGlobal()
+		ion.meterProvider.SetGlobal()
+		zapRes.OTELProvider.SetGlobal()
+		routeOTELErrors()
+	}
+
+	ion.warnings = warnings
 	return ion, warnings, nil
 }
 
+// mergeAttributes returns a new map holding base overlaid with override.
+// Returns nil when both are empty.
+func mergeAttributes(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
 // --- Logger interface implementation (Named/With shadow promoted methods) ---
 
 // Named returns a child Ion instance with a named sub-logger.
@@ -214,6 +295,8 @@ func (i *Ion) Named(name string) Logger {
 		tracingEnabled: i.tracingEnabled,
 		meterProvider:  i.meterProvider,
 		metricsEnabled: i.metricsEnabled,
+		warnings:       i.warnings,
+		goroutines:     i.goroutines,
 	}
 }
 
@@ -233,6 +316,8 @@ func (i *Ion) With(fields ...Field) Logger {
 		tracingEnabled: i.tracingEnabled,
 		meterProvider:  i.meterProvider,
 		metricsEnabled: i.metricsEnabled,
+		warnings:       i.warnings,
+		goroutines:     i.goroutines,
 	}
 }
 
@@ -264,26 +349,46 @@ func (i *Ion) Child(name string, fields ...Field) *Ion {
 		tracingEnabled: i.tracingEnabled,
 		meterProvider:  i.meterProvider,
 		metricsEnabled: i.metricsEnabled,
+		warnings:       i.warnings,
+		goroutines:     i.goroutines,
 	}
 }
 
 // --- Tracer access ---
 
-var tracingDisabledLogged bool
+var tracingDisabledLogged atomic.Bool
+
+var errTracingDisabled = errors.New("tracing disabled: Tracer() returning no-op. Enable via Config.Tracing.Enabled")
 
 // Tracer returns a named tracer for creating spans.
-// If tracing is not enabled, returns a no-op tracer (logs warning once).
+// If tracing is not enabled, returns a no-op tracer (reported once via the
+// handler set by [SetErrorHandler]).
 func (i *Ion) Tracer(name string) Tracer {
 	if !i.tracingEnabled || i.tracerProvider == nil {
-		if !tracingDisabledLogged {
-			tracingDisabledLogged = true
-			log.Println("[ion] Tracing disabled: Tracer() returning no-op. Enable via Config.Tracing.Enabled")
+		if tracingDisabledLogged.CompareAndSwap(false, true) {
+			handleError(errTracingDisabled)
 		}
 		return noopTracer{}
 	}
-	// core.SetupTracerProvider sets the global OTEL provider,
-	// so newOTELTracer(name) which calls otel.Tracer(name) works correctly.
-	return newOTELTracer(name)
+	return newOTELTracer(i.tracerProvider.Tracer(name))
+}
+
+// TracerProvider returns the instance's tracer provider, for instrumentation
+// that takes one (e.g. ionhttp.WithTracerProvider). If tracing is not
+// enabled, a no-op provider is returned.
+func (i *Ion) TracerProvider() trace.TracerProvider {
+	if !i.tracingEnabled || i.tracerProvider.TracerProvider() == nil {
+		return tracenoop.NewTracerProvider()
+	}
+	return i.tracerProvider.TracerProvider()
+}
+
+// Propagator returns the TextMapPropagator configured via [TracingConfig].Propagators.
+// Pass it to the ionhttp and iongrpc middleware (WithPropagators) to inject and
+// extract headers in the configured formats. If tracing is not enabled, the
+// global OTEL propagator is returned.
+func (i *Ion) Propagator() propagation.TextMapPropagator {
+	return i.tracerProvider.Propagator()
 }
 
 // --- Metrics access ---
@@ -297,6 +402,32 @@ func (i *Ion) Meter(name string, opts ...metric.MeterOption) metric.Meter {
 	return i.meterProvider.Meter(name, opts...)
 }
 
+// MeterProvider returns the instance's meter provider, for instrumentation
+// that takes one (e.g. ionhttp.WithMeterProvider). If metrics are not
+// enabled, a no-op provider is returned.
+func (i *Ion) MeterProvider() metric.MeterProvider {
+	if !i.metricsEnabled || i.meterProvider.MeterProvider() == nil {
+		return noop.NewMeterProvider()
+	}
+	return i.meterProvider.MeterProvider()
+}
+
+// MetricsHandler returns an http.Handler serving this instance's metrics in
+// the Prometheus exposition format, for Config.Metrics.Protocol "prometheus"
+// or Config.Metrics.Prometheus. Resource attributes appear as labels of the
+// target_info metric. If the Prometheus reader is not enabled, the handler
+// responds 404 Not Found.
+//
+//	mux.Handle("/metrics", app.MetricsHandler())
+func (i *Ion) MetricsHandler() http.Handler {
+	if h := i.meterProvider.Handler(); h != nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "prometheus metrics are not enabled (set Metrics.Protocol to \"prometheus\" or Metrics.Prometheus)", http.StatusNotFound)
+	})
+}
+
 // newNoopMeter returns a no-op meter that satisfies the metric.Meter interface
 // without recording any data. Used when metrics are disabled.
 func newNoopMeter() metric.Meter {
@@ -305,8 +436,33 @@ func newNoopMeter() metric.Meter {
 
 // --- Lifecycle ---
 
+// ForceFlush exports everything buffered so far: ended spans, metrics from
+// push readers, and log records for OTEL, then syncs the log outputs.
+// Providers stay running. Use it in tests before inspecting an exporter, or
+// before a planned exit that cannot call Shutdown.
+//
+// Returns the first error encountered, but always attempts every subsystem.
+func (i *Ion) ForceFlush(ctx context.Context) error {
+	var firstErr error
+	record := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	record(i.tracerProvider.ForceFlush(ctx))
+	record(i.meterProvider.ForceFlush(ctx))
+	if i.zapLogger != nil {
+		record(i.otelProvider.ForceFlush(ctx))
+		record(i.zap.Sync())
+	}
+	return firstErr
+}
+
 // Shutdown gracefully shuts down all observability subsystems in order:
 // tracing provider, metrics provider, then the logging backend (including OTEL log export).
+// Components added through [Option]s are shut down with the provider they
+// belong to; extra zap cores are synced last.
 //
 // The provided context controls the shutdown deadline. Returns the first error
 // encountered, but always attempts to shut down all subsystems.
@@ -2,9 +2,19 @@ package ion
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
-
+	"time"
+
+	"go.opentelemetry.io/otel"
+	logglobal "go.opentelemetry.io/otel/log/global"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
@@ -101,6 +111,230 @@ func TestIon_Shutdown(t *testing.T) {
 	}
 }
 
+// shutdownRecorder records the order in which injected components shut down.
+type shutdownRecorder struct{ order []string }
+
+type recordedSpanExporter struct {
+	sdktrace.SpanExporter
+	r *shutdownRecorder
+}
+
+func (e recordedSpanExporter) Shutdown(ctx context.Context) error {
+	e.r.order = append(e.r.order, "traces")
+	return e.SpanExporter.Shutdown(ctx)
+}
+
+type recordedMetricReader struct {
+	sdkmetric.Reader
+	r *shutdownRecorder
+}
+
+func (m recordedMetricReader) Shutdown(ctx context.Context) error {
+	m.r.order = append(m.r.order, "metrics")
+	return m.Reader.Shutdown(ctx)
+}
+
+type recordedLogExporter struct {
+	recordingLogExporter
+	r *shutdownRecorder
+}
+
+func (e *recordedLogExporter) Shutdown(context.Context) error {
+	e.r.order = append(e.r.order, "logs")
+	return nil
+}
+
+type recordedCore struct {
+	zapcore.Core
+	r *shutdownRecorder
+}
+
+func (c recordedCore) With(fields []zapcore.Field) zapcore.Core {
+	return recordedCore{Core: c.Core.With(fields), r: c.r}
+}
+
+func (c recordedCore) Sync() error {
+	c.r.order = append(c.r.order, "sync")
+	return nil
+}
+
+func TestIon_ShutdownOrder(t *testing.T) {
+	rec := &shutdownRecorder{}
+	obsCore, _ := observer.New(zapcore.DebugLevel)
+	cfg := Default()
+	cfg.Console.Enabled = false
+	app, _, err := New(cfg,
+		WithSpanExporter(recordedSpanExporter{SpanExporter: tracetest.NewInMemoryExporter(), r: rec}),
+		WithMetricReader(recordedMetricReader{Reader: sdkmetric.NewManualReader(), r: rec}),
+		WithLogExporter(&recordedLogExporter{r: rec}),
+		WithZapCore(recordedCore{Core: obsCore, r: rec}),
+	)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error: %v", err)
+	}
+	if got, want := strings.Join(rec.order, ","), "traces,metrics,logs,sync"; got != want {
+		t.Errorf("shutdown order = %s, want %s", got, want)
+	}
+}
+
+// restoreOTELGlobals puts back the OTEL globals that SetGlobalProviders
+// replaces once the test ends, so later tests see the defaults.
+func restoreOTELGlobals(t *testing.T) {
+	t.Helper()
+	tp, mp, lp := otel.GetTracerProvider(), otel.GetMeterProvider(), logglobal.GetLoggerProvider()
+	prop := otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		// OTEL logs a complaint when a global is set to its current value.
+		if !sameValue(otel.GetTracerProvider(), tp) {
+			otel.SetTracerProvider(tp)
+		}
+		if !sameValue(otel.GetMeterProvider(), mp) {
+			otel.SetMeterProvider(mp)
+		}
+		if !sameValue(logglobal.GetLoggerProvider(), lp) {
+			logglobal.SetLoggerProvider(lp)
+		}
+		if !sameValue(otel.GetTextMapPropagator(), prop) {
+			otel.SetTextMapPropagator(prop)
+		}
+	})
+}
+
+// sameValue reports a == b without panicking on uncomparable types such as
+// composite propagators.
+func sameValue(a, b any) bool {
+	ta := reflect.TypeOf(a)
+	return ta == reflect.TypeOf(b) && ta != nil && ta.Comparable() && a == b
+}
+
+func TestIon_ProvidersArePerInstance(t *testing.T) {
+	restoreOTELGlobals(t)
+	newApp := func(cfg Config) (*Ion, *tracetest.InMemoryExporter) {
+		exp := tracetest.NewInMemoryExporter()
+		cfg.Console.Enabled = false
+		cfg.Tracing.Sampler = "always"
+		app, _, err := New(cfg, WithSpanExporter(exp))
+		if err != nil {
+			t.Fatalf("New() error: %v", err)
+		}
+		t.Cleanup(func() { _ = app.Shutdown(context.Background()) })
+		return app, exp
+	}
+	before := otel.GetTracerProvider()
+	node, nodeExp := newApp(Default())
+	client, clientExp := newApp(Default())
+
+	if otel.GetTracerProvider() != before {
+		t.Fatal("New() replaced the global tracer provider without SetGlobalProviders")
+	}
+	_, span := node.Tracer("node").Start(context.Background(), "node-op")
+	span.End()
+	_, span = client.Tracer("client").Start(context.Background(), "client-op")
+	span.End()
+	_ = node.ForceFlush(context.Background())
+	_ = client.ForceFlush(context.Background())
+
+	if got := nodeExp.GetSpans(); len(got) != 1 || got[0].Name != "node-op" {
+		t.Errorf("node exported %v, want only node-op", got)
+	}
+	if got := clientExp.GetSpans(); len(got) != 1 || got[0].Name != "client-op" {
+		t.Errorf("client exported %v, want only client-op", got)
+	}
+
+	cfg := Default()
+	cfg.SetGlobalProviders = true
+	global, _ := newApp(cfg)
+	if otel.GetTracerProvider() != global.TracerProvider() {
+		t.Error("SetGlobalProviders did not install the tracer provider")
+	}
+}
+
+func TestIon_MetricsHandler(t *testing.T) {
+	cfg := Default()
+	cfg.Console.Enabled = false
+	cfg.ServiceName = "validator"
+	cfg.Metrics.Enabled = true
+	cfg.Metrics.Protocol = "prometheus"
+	cfg.Metrics.Attributes = map[string]string{"chain": "solana"}
+	app, _, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = app.Shutdown(context.Background()) }()
+
+	counter, _ := app.Meter("node").Int64Counter("blocks.applied")
+	counter.Add(context.Background(), 2)
+
+	rec := httptest.NewRecorder()
+	app.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{"blocks_applied_total", `target_info{`, `service_name="validator"`, `chain="solana"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape missing %s:\n%s", want, body)
+		}
+	}
+
+	disabled, _, _ := New(Default())
+	rec = httptest.NewRecorder()
+	disabled.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status without prometheus = %d, want 404", rec.Code)
+	}
+}
+
+func TestIon_ExportQueue(t *testing.T) {
+	cfg := Default()
+	cfg.Console.Enabled = false
+	cfg.OTEL.Queue = QueueConfig{Enabled: true, Dir: t.TempDir(), RetryInterval: time.Hour}
+	cfg.Metrics.Enabled = true
+	cfg.Metrics.Protocol = "http"
+	cfg.Metrics.Endpoint = "127.0.0.1:1" // nothing listens here
+	cfg.Metrics.Insecure = true
+	cfg.Metrics.Timeout = 100 * time.Millisecond
+	cfg.Metrics.Interval = time.Hour
+	reader := sdkmetric.NewManualReader()
+	app, warnings, err := New(cfg, WithMetricReader(reader))
+	if err != nil || len(warnings) > 0 {
+		t.Fatalf("New() = %v, warnings %v", err, warnings)
+	}
+	defer func() { _ = app.Shutdown(context.Background()) }()
+
+	counter, _ := app.Meter("node").Int64Counter("blocks.applied")
+	counter.Add(context.Background(), 1)
+	if err := app.meterProvider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() = %v, want nil once queued", err)
+	}
+
+	stats := app.meterProvider.Queue().Stats()
+	if stats.Queued == 0 || stats.Pending == 0 {
+		t.Fatalf("queue stats = %+v, want the failed export stored", stats)
+	}
+	status := AdminHandler(app).(*adminHandler).status()
+	if q := status.Signals["metrics"].Queue; q == nil || q.Pending != stats.Pending {
+		t.Errorf("status metrics queue = %+v, want %+v", q, stats)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "ion.export_queue.queued" {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Error("ion.export_queue.queued not reported")
+	}
+}
+
 // --- Phase 5: New tests for Solution 4 ---
 
 // TestIon_CallerDepth verifies that log output reports the test file
@@ -303,3 +537,41 @@ func TestIon_InterfaceCompliance(t *testing.T) {
 		t.Fatal("Named() result should satisfy Logger interface")
 	}
 }
+
+// TestIon_SetLevelFor verifies that component levels apply to a child and its
+// descendants without changing the level of siblings.
+func TestIon_SetLevelFor(t *testing.T) {
+	app, _, _ := New(Default())
+	p2p := app.Child("p2p")
+	gossip := p2p.Child("gossip")
+	consensus := app.Child("consensus")
+
+	if err := app.SetLevelFor("p2p", "debug"); err != nil {
+		t.Fatalf("SetLevelFor() error: %v", err)
+	}
+
+	if got := gossip.LevelFor("p2p.gossip"); got != "debug" {
+		t.Errorf("LevelFor(p2p.gossip) = %q, want \"debug\"", got)
+	}
+	if !gossip.enabled(zapcore.DebugLevel) {
+		t.Error("p2p.gossip should have debug enabled via ancestor")
+	}
+	if consensus.enabled(zapcore.DebugLevel) {
+		t.Error("consensus should not have debug enabled")
+	}
+	if got := app.GetLevel(); got != "info" {
+		t.Errorf("global level = %q, want \"info\"", got)
+	}
+
+	if err := app.SetLevelFor("p2p", "loud"); err == nil {
+		t.Error("SetLevelFor() with invalid level should return an error")
+	}
+
+	// Clearing restores inheritance from the global level.
+	if err := app.SetLevelFor("p2p", ""); err != nil {
+		t.Fatalf("SetLevelFor() clear error: %v", err)
+	}
+	if p2p.enabled(zapcore.DebugLevel) {
+		t.Error("p2p should not have debug enabled after clearing")
+	}
+}
@@ -0,0 +1,247 @@
+// Package iontest provides an in-memory ion instance for unit tests.
+//
+// [New] returns a real *ion.Ion whose logs, spans, and metrics are recorded
+// in memory instead of being written or exported, plus an [Observer] to
+// query them:
+//
+//	app, obs := iontest.New(t)
+//	svc := NewService(app)
+//	svc.Submit(ctx, tx)
+//
+//	obs.AssertLogged(t, "error", "submit failed", fields.TxHash(tx.Hash))
+//	if spans := obs.Spans(); len(spans) != 1 { ... }
+//	if n := obs.Counter(t, "tx.submitted"); n != 1 { ... }
+package iontest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/JupiterMetaLabs/ion"
+)
+
+// Observer records what an ion instance from [New] logs, traces, and measures.
+type Observer struct {
+	logs   *observer.ObservedLogs
+	spans  *tracetest.SpanRecorder
+	reader *sdkmetric.ManualReader
+}
+
+// New returns an ion instance at debug level with console output disabled,
+// recording logs, every span (sampler "always"), and metrics in memory.
+// It is shut down when the test ends.
+func New(t testing.TB, opts ...ion.Option) (*ion.Ion, *Observer) {
+	t.Helper()
+	cfg := ion.Default()
+	cfg.ServiceName = "iontest"
+	cfg.Level = "debug"
+	cfg.Console.Enabled = false
+	cfg.Tracing.Sampler = "always"
+	return NewWithConfig(t, cfg, opts...)
+}
+
+// NewWithConfig is like [New] but starts from cfg, e.g. to test redaction or
+// component levels. Outputs enabled in cfg are written as usual.
+func NewWithConfig(t testing.TB, cfg ion.Config, opts ...ion.Option) (*ion.Ion, *Observer) {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	obs := &Observer{
+		logs:   logs,
+		spans:  tracetest.NewSpanRecorder(),
+		reader: sdkmetric.NewManualReader(),
+	}
+
+	opts = append([]ion.Option{
+		ion.WithZapCore(core),
+		ion.WithSpanProcessor(obs.spans),
+		ion.WithMetricReader(obs.reader),
+	}, opts...)
+	app, warnings, err := ion.New(cfg, opts...)
+	if err != nil {
+		t.Fatalf("iontest: ion.New() error: %v", err)
+	}
+	for _, w := range warnings {
+		t.Logf("iontest: %v", w)
+	}
+	t.Cleanup(func() { _ = app.Shutdown(context.Background()) })
+	return app, obs
+}
+
+// --- Logs ---
+
+// Logs is a snapshot of recorded log entries.
+type Logs []observer.LoggedEntry
+
+// Entries returns all recorded log entries, oldest first.
+func (o *Observer) Entries() Logs {
+	return Logs(o.logs.All())
+}
+
+// FilterMessage returns the entries with exactly this message.
+func (o *Observer) FilterMessage(msg string) Logs {
+	return o.Entries().FilterMessage(msg)
+}
+
+// FilterField returns the entries carrying f with an equal value.
+func (o *Observer) FilterField(f ion.Field) Logs {
+	return o.Entries().FilterField(f)
+}
+
+// Reset discards the recorded log entries.
+func (o *Observer) Reset() {
+	o.logs.TakeAll()
+}
+
+// AssertLogged fails t unless an entry was logged at level (debug, info,
+// warn, error, or critical) with all of fields. An empty msg matches any
+// message.
+func (o *Observer) AssertLogged(t testing.TB, level, msg string, fields ...ion.Field) {
+	t.Helper()
+	matches := o.Entries().FilterLevel(level)
+	if msg != "" {
+		matches = matches.FilterMessage(msg)
+	}
+	for _, f := range fields {
+		matches = matches.FilterField(f)
+	}
+	if len(matches) > 0 {
+		return
+	}
+
+	var b strings.Builder
+	for _, e := range o.logs.All() {
+		fmt.Fprintf(&b, "\n  %s %q %v", e.Level, e.Message, e.ContextMap())
+	}
+	t.Errorf("no %s entry %q with fields %v; recorded:%s", level, msg, fieldMap(fields), b.String())
+}
+
+// Len returns the number of entries.
+func (l Logs) Len() int { return len(l) }
+
+// FilterMessage returns the entries with exactly this message.
+func (l Logs) FilterMessage(msg string) Logs {
+	return l.filter(func(e observer.LoggedEntry) bool { return e.Message == msg })
+}
+
+// FilterLevel returns the entries at level. "critical" matches Critical
+// entries, which are written at fatal level.
+func (l Logs) FilterLevel(level string) Logs {
+	if strings.EqualFold(level, "critical") {
+		level = "fatal"
+	}
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil
+	}
+	return l.filter(func(e observer.LoggedEntry) bool { return e.Level == lvl })
+}
+
+// FilterField returns the entries carrying f with an equal value. Values are
+// compared in their printed form, so ion.Int("n", 1) matches an int64 1 and
+// ion.Err(err) matches the error message.
+func (l Logs) FilterField(f ion.Field) Logs {
+	want := fmt.Sprint(fieldValue(f))
+	return l.filter(func(e observer.LoggedEntry) bool {
+		got, ok := e.ContextMap()[f.Key]
+		return ok && fmt.Sprint(got) == want
+	})
+}
+
+func (l Logs) filter(keep func(observer.LoggedEntry) bool) Logs {
+	var out Logs
+	for _, e := range l {
+		if keep(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// fieldValue returns the value an encoder would write for f.
+func fieldValue(f ion.Field) any {
+	switch f.Type {
+	case ion.StringType:
+		return f.StringVal
+	case ion.Int64Type:
+		return f.Integer
+	case ion.Float64Type:
+		return f.Float
+	case ion.BoolType:
+		return f.Integer == 1
+	case ion.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return err.Error()
+		}
+	}
+	return f.Interface
+}
+
+func fieldMap(fields []ion.Field) map[string]any {
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		m[f.Key] = fieldValue(f)
+	}
+	return m
+}
+
+// --- Spans ---
+
+// Spans returns the ended spans, in the order they ended.
+func (o *Observer) Spans() []sdktrace.ReadOnlySpan {
+	return o.spans.Ended()
+}
+
+// FindSpan returns the first ended span with the given name, or nil.
+func (o *Observer) FindSpan(name string) sdktrace.ReadOnlySpan {
+	for _, s := range o.spans.Ended() {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// --- Metrics ---
+
+// Metrics collects the current metrics from the manual reader.
+func (o *Observer) Metrics(t testing.TB) metricdata.ResourceMetrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := o.reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("iontest: collect metrics: %v", err)
+	}
+	return rm
+}
+
+// Counter returns the total of the Int64 counter (or up-down counter) named
+// name across all attribute sets. It fails t if no such metric was recorded.
+func (o *Observer) Counter(t testing.TB, name string) int64 {
+	t.Helper()
+	for _, sm := range o.Metrics(t).ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("iontest: metric %q is %T, not an int64 sum", name, m.Data)
+			}
+			var total int64
+			for _, dp := range sum.DataPoints {
+				total += dp.Value
+			}
+			return total
+		}
+	}
+	t.Fatalf("iontest: no metric named %q", name)
+	return 0
+}
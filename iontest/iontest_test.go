@@ -0,0 +1,94 @@
+package iontest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/JupiterMetaLabs/ion"
+)
+
+func TestNew_Logs(t *testing.T) {
+	app, obs := New(t)
+	ctx := context.Background()
+
+	app.Debug(ctx, "mempool scan", ion.Int("pending", 12))
+	app.Named("rpc").Error(ctx, "submit failed", errors.New("nonce too low"), ion.String("tx_hash", "0xabc"))
+
+	if got := obs.Entries().Len(); got != 2 {
+		t.Fatalf("Entries().Len() = %d, want 2", got)
+	}
+	if got := obs.FilterMessage("mempool scan").FilterField(ion.Int("pending", 12)).Len(); got != 1 {
+		t.Errorf("FilterField(pending=12) = %d entries, want 1", got)
+	}
+	if got := obs.FilterField(ion.String("tx_hash", "0xdef")).Len(); got != 0 {
+		t.Errorf("FilterField(tx_hash=0xdef) = %d entries, want 0", got)
+	}
+	obs.AssertLogged(t, "error", "submit failed", ion.String("tx_hash", "0xabc"), ion.Err(errors.New("nonce too low")))
+	obs.AssertLogged(t, "debug", "")
+
+	obs.Reset()
+	if got := obs.Entries().Len(); got != 0 {
+		t.Errorf("Entries().Len() after Reset = %d, want 0", got)
+	}
+}
+
+func TestNew_AssertLoggedFails(t *testing.T) {
+	_, obs := New(t)
+	ft := &fakeT{TB: t}
+	obs.AssertLogged(ft, "warn", "never logged")
+	if !ft.failed {
+		t.Error("AssertLogged() did not fail for a missing entry")
+	}
+}
+
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeT) Errorf(string, ...any) { f.failed = true }
+func (f *fakeT) Helper()               {}
+
+func TestNew_Spans(t *testing.T) {
+	app, obs := New(t)
+
+	ctx, parent := app.Tracer("block").Start(context.Background(), "ApplyBlock")
+	_, child := app.Tracer("block").Start(ctx, "VerifyTx", ion.WithAttributes(attribute.Int64("tx.count", 3)))
+	child.End()
+	parent.End()
+
+	spans := obs.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("Spans() = %d, want 2", len(spans))
+	}
+	verify := obs.FindSpan("VerifyTx")
+	if verify == nil {
+		t.Fatal("FindSpan(VerifyTx) = nil")
+	}
+	if verify.Parent().SpanID() != obs.FindSpan("ApplyBlock").SpanContext().SpanID() {
+		t.Error("VerifyTx is not a child of ApplyBlock")
+	}
+	if attrs := verify.Attributes(); len(attrs) != 1 || attrs[0].Value.AsInt64() != 3 {
+		t.Errorf("VerifyTx attributes = %v, want tx.count=3", attrs)
+	}
+}
+
+func TestNew_Metrics(t *testing.T) {
+	app, obs := New(t)
+	ctx := context.Background()
+
+	counter, err := app.Meter("tx").Int64Counter("tx.submitted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter.Add(ctx, 2)
+	counter.Add(ctx, 3, metric.WithAttributes(attribute.String("type", "transfer")))
+
+	if got := obs.Counter(t, "tx.submitted"); got != 5 {
+		t.Errorf("Counter(tx.submitted) = %d, want 5", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -16,7 +17,20 @@ type zapLogger struct {
 	zap          *zap.Logger
 	config       Config
 	atomicLvl    zap.AtomicLevel
+	levels       *core.Levels // nil when built without per-component levels (tests)
 	otelProvider *core.LogProvider
+	spanEvents   *core.SpanEvents // nil unless Tracing.LogsAsEvents is enabled
+	eventFields  []zap.Field      // With() fields, kept for span events
+	extractors   []ContextExtractor
+}
+
+// enabled reports whether an entry at lvl from this logger would reach any output.
+// It is checked before fields are prepared so disabled levels cost no allocations.
+func (l *zapLogger) enabled(lvl zapcore.Level) bool {
+	if l.levels != nil {
+		return l.levels.Enabled(l.zap.Name(), lvl)
+	}
+	return l.atomicLvl.Enabled(lvl)
 }
 
 // prepareFields consolidates context extraction and field conversion.
@@ -28,6 +42,12 @@ func (l *zapLogger) prepareFields(ctx context.Context, fields []Field) []zap.Fie
 	if ctx != nil && ctx != context.Background() && ctx != context.TODO() {
 		// Extract readable trace_id/span_id strings for console/file
 		contextFields := extractContextZapFields(ctx)
+		for _, extract := range l.extractors {
+			contextFields = append(contextFields, toZapFields(extract(ctx))...)
+		}
+		if len(zapFields) > 0 {
+			contextFields = dropShadowed(contextFields, zapFields)
+		}
 		// Add ctx for otelzap bridge to extract LogRecord.TraceID/SpanID
 		contextFields = append(contextFields, zap.Reflect(core.SentinelKey, ctx))
 		zapFields = append(zapFields, contextFields...)
@@ -36,35 +56,63 @@ func (l *zapLogger) prepareFields(ctx context.Context, fields []Field) []zap.Fie
 	return zapFields
 }
 
+// dropShadowed removes context fields whose key is also set by a per-call
+// field, so the per-call value wins instead of producing a duplicate key.
+func dropShadowed(contextFields, callFields []zap.Field) []zap.Field {
+	out := contextFields[:0]
+	for _, f := range contextFields {
+		if !slices.ContainsFunc(callFields, func(c zap.Field) bool { return c.Key == f.Key }) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// recordEvent adds the entry to the recording span in ctx when
+// Tracing.LogsAsEvents is enabled for lvl. It is a no-op otherwise.
+func (l *zapLogger) recordEvent(ctx context.Context, lvl zapcore.Level, msg string, err error, fields []Field) {
+	if !l.spanEvents.Enabled(lvl) {
+		return
+	}
+	eventFields := l.eventFields
+	if len(fields) > 0 {
+		eventFields = append(eventFields[:len(eventFields):len(eventFields)], toZapFields(fields)...)
+	}
+	l.spanEvents.Record(ctx, lvl, l.zap.Name(), msg, err, eventFields)
+}
+
 // Debug logs a message at debug level.
 func (l *zapLogger) Debug(ctx context.Context, msg string, fields ...Field) {
-	if !l.atomicLvl.Enabled(zapcore.DebugLevel) {
+	if !l.enabled(zapcore.DebugLevel) {
 		return
 	}
 	// Stack depth: User -> (*zapLogger).Debug (promoted via embedding in Ion)
 	// Zap skips: 1 (configured in core/logger_factory.go:152)
 	l.zap.Debug(msg, l.prepareFields(ctx, fields)...)
+	l.recordEvent(ctx, zapcore.DebugLevel, msg, nil, fields)
 }
 
 // Info logs a message at info level.
 func (l *zapLogger) Info(ctx context.Context, msg string, fields ...Field) {
-	if !l.atomicLvl.Enabled(zapcore.InfoLevel) {
+	if !l.enabled(zapcore.InfoLevel) {
 		return
 	}
 	l.zap.Info(msg, l.prepareFields(ctx, fields)...)
+	l.recordEvent(ctx, zapcore.InfoLevel, msg, nil, fields)
 }
 
 // Warn logs a message at warn level.
 func (l *zapLogger) Warn(ctx context.Context, msg string, fields ...Field) {
-	if !l.atomicLvl.Enabled(zapcore.WarnLevel) {
+	if !l.enabled(zapcore.WarnLevel) {
 		return
 	}
 	l.zap.Warn(msg, l.prepareFields(ctx, fields)...)
+	l.recordEvent(ctx, zapcore.WarnLevel, msg, nil, fields)
 }
 
 // Error logs a message at error level with an optional error.
 func (l *zapLogger) Error(ctx context.Context, msg string, err error, fields ...Field) {
-	if !l.atomicLvl.Enabled(zapcore.ErrorLevel) {
+	if !l.enabled(zapcore.ErrorLevel) {
 		return
 	}
 
@@ -74,6 +122,7 @@ func (l *zapLogger) Error(ctx context.Context, msg string, err error, fields ...
 	}
 
 	l.zap.Error(msg, zapFields...)
+	l.recordEvent(ctx, zapcore.ErrorLevel, msg, err, fields)
 }
 
 // Critical logs a message at fatal level but does NOT exit the process.
@@ -86,6 +135,7 @@ func (l *zapLogger) Critical(ctx context.Context, msg string, err error, fields
 	}
 
 	l.zap.Fatal(msg, zapFields...)
+	l.recordEvent(ctx, zapcore.FatalLevel, msg, err, fields)
 }
 
 // With returns a child logger with additional fields attached to every log entry.
@@ -106,7 +156,11 @@ func (l *zapLogger) namedInternal(name string) *zapLogger {
 		zap:          l.zap.Named(name),
 		config:       l.config,
 		atomicLvl:    l.atomicLvl,
+		levels:       l.levels,
 		otelProvider: l.otelProvider,
+		spanEvents:   l.spanEvents,
+		eventFields:  l.eventFields,
+		extractors:   l.extractors,
 	}
 }
 
@@ -114,11 +168,20 @@ func (l *zapLogger) namedInternal(name string) *zapLogger {
 // Used by Ion.With() to construct a child while preserving the concrete type
 // for wrapping in a new *Ion.
 func (l *zapLogger) withInternal(fields ...Field) *zapLogger {
+	zapFields := toZapFields(fields)
+	eventFields := l.eventFields
+	if l.spanEvents != nil && len(zapFields) > 0 {
+		eventFields = append(eventFields[:len(eventFields):len(eventFields)], zapFields...)
+	}
 	return &zapLogger{
-		zap:          l.zap.With(toZapFields(fields)...),
+		zap:          l.zap.With(zapFields...),
 		config:       l.config,
 		atomicLvl:    l.atomicLvl,
+		levels:       l.levels,
 		otelProvider: l.otelProvider,
+		spanEvents:   l.spanEvents,
+		eventFields:  eventFields,
+		extractors:   l.extractors,
 	}
 }
 
@@ -166,6 +229,61 @@ func (l *zapLogger) GetLevel() string {
 	return l.atomicLvl.Level().String()
 }
 
+// SetLevelFor sets the log level for the named component and its descendants
+// at runtime. Names are the dotted logger names built by Child/Named
+// (e.g. "p2p", "consensus.vote"); "*" sets the global level.
+// An empty level removes the override so the component inherits again.
+//
+// Outputs with their own configured level (Console.Level, File.Level, OTEL.Level)
+// are not affected by component levels.
+func (l *zapLogger) SetLevelFor(name, level string) error {
+	if l.levels == nil {
+		return errors.New("per-component levels are not available on this logger")
+	}
+	if level == "" {
+		l.levels.ClearComponent(name)
+		return nil
+	}
+	lvl, err := core.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	l.levels.SetComponent(name, lvl)
+	return nil
+}
+
+// LevelFor returns the effective log level for the named component:
+// its own override, its nearest configured ancestor's, or the global level.
+func (l *zapLogger) LevelFor(name string) string {
+	if l.levels == nil {
+		return l.atomicLvl.Level().String()
+	}
+	return l.levels.Effective(name).String()
+}
+
+// SetOutputLevel pins one output ("console", "file", "otel") to a level at
+// runtime, independent of the global and component levels. An empty level
+// removes the pin so the output follows the global and component levels again.
+func (l *zapLogger) SetOutputLevel(output, level string) error {
+	if l.levels == nil {
+		return errors.New("per-output levels are not available on this logger")
+	}
+	sink := l.levels.Sink(output)
+	if sink == nil {
+		return fmt.Errorf("output %q is not enabled", output)
+	}
+	if level == "" {
+		sink.Inherit()
+		return nil
+	}
+	lvl, err := core.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	sink.Set(lvl)
+	return nil
+}
+
 // --- Field conversion ---
 
 // convertField maps an Ion Field to a zap.Field.
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"context"
 	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // Helper to replace the removed internal function
@@ -247,6 +250,99 @@ func TestContextHelpers(t *testing.T) {
 	}
 }
 
+func TestContextWithFields(t *testing.T) {
+	ctx := ContextWithFields(context.Background(), Uint64("block_height", 1), String("peer_id", "p1"))
+	ctx = ContextWithFields(ctx, Uint64("block_height", 2), String("shard", "s3"))
+
+	got := FieldsFromContext(ctx)
+	want := []struct{ key, value string }{{"block_height", "2"}, {"peer_id", "p1"}, {"shard", "s3"}}
+	if len(got) != len(want) {
+		t.Fatalf("FieldsFromContext() = %v, want %d fields", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].Key != w.key {
+			t.Errorf("field %d key = %q, want %q", i, got[i].Key, w.key)
+		}
+	}
+	if got[0].Interface != uint64(2) {
+		t.Errorf("block_height = %v, want the overriding 2", got[0].Interface)
+	}
+
+	if ContextWithFields(ctx) != ctx {
+		t.Error("ContextWithFields with no fields should return ctx unchanged")
+	}
+	if f := FieldsFromContext(context.Background()); f != nil {
+		t.Errorf("FieldsFromContext(Background) = %v, want nil", f)
+	}
+}
+
+func TestLogger_ContextFieldsAndExtractors(t *testing.T) {
+	type peerKey struct{}
+	obsCore, logs := observer.New(zapcore.DebugLevel)
+	cfg := Default()
+	cfg.Console.Enabled = false
+	app, _, err := New(cfg,
+		WithZapCore(obsCore),
+		WithContextExtractor(func(ctx context.Context) []Field {
+			if p, ok := ctx.Value(peerKey{}).(string); ok {
+				return []Field{String("peer", p)}
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = app.Shutdown(context.Background()) }()
+
+	ctx := ContextWithFields(context.Background(), Uint64("block_height", 7))
+	ctx = context.WithValue(WithRequestID(ctx, "req-1"), peerKey{}, "p1")
+	app.Named("sync").With(String("component", "x")).Info(ctx, "block applied")
+	app.Info(context.Background(), "background")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d entries, want 2", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	for key, want := range map[string]any{"block_height": uint64(7), "request_id": "req-1", "peer": "p1", "component": "x"} {
+		if fields[key] != want {
+			t.Errorf("%s = %v, want %v", key, fields[key], want)
+		}
+	}
+	if _, ok := entries[1].ContextMap()["peer"]; ok {
+		t.Error("extractor ran for context.Background()")
+	}
+}
+
+func TestContextFields_PerCallKeyWins(t *testing.T) {
+	obsCore, logs := observer.New(zapcore.DebugLevel)
+	cfg := Default()
+	cfg.Console.Enabled = false
+	app, _, err := New(cfg, WithZapCore(obsCore))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = app.Shutdown(context.Background()) }()
+
+	ctx := ContextWithFields(WithRequestID(context.Background(), "req-1"), Uint64("block_height", 7), String("peer_id", "p1"))
+	app.Info(ctx, "block applied", Uint64("block_height", 8), String("request_id", "req-2"))
+
+	counts := map[string]int{}
+	for _, f := range logs.All()[0].Context {
+		counts[f.Key]++
+	}
+	for _, key := range []string{"block_height", "request_id", "peer_id"} {
+		if counts[key] != 1 {
+			t.Errorf("%s appears %d times, want once", key, counts[key])
+		}
+	}
+	fields := logs.All()[0].ContextMap()
+	if fields["block_height"] != uint64(8) || fields["request_id"] != "req-2" {
+		t.Errorf("fields = %v, want the per-call block_height and request_id", fields)
+	}
+}
+
 // Silence the test output
 var _ = bytes.Buffer{}
 
@@ -0,0 +1,104 @@
+package ion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewLogr returns a [logr.Logger] that writes to l. See [NewLogrSink].
+func NewLogr(l Logger) logr.Logger {
+	return logr.New(NewLogrSink(l))
+}
+
+// NewLogrSink returns a [logr.LogSink] that writes to l, for libraries that
+// log through go-logr (controller-runtime, client-go, the OTel SDK).
+//
+// V(0) maps to info and V(1) and above to debug; errors map to error.
+// WithName adds a component via [Logger.Named], so per-component levels apply.
+// Key/value pairs become fields.
+func NewLogrSink(l Logger) logr.LogSink {
+	return &logrSink{l: l}
+}
+
+// logrSink adapts a Logger to logr.LogSink.
+type logrSink struct {
+	l Logger
+}
+
+// logrLevel maps a logr V-level to a zap level.
+func logrLevel(v int) zapcore.Level {
+	if v <= 0 {
+		return zapcore.InfoLevel
+	}
+	return zapcore.DebugLevel
+}
+
+// Init is a no-op; call depth is not adjustable through Logger.
+func (s *logrSink) Init(logr.RuntimeInfo) {}
+
+// Enabled reports whether V-level v would be written.
+func (s *logrSink) Enabled(v int) bool {
+	return loggerEnabled(s.l, logrLevel(v))
+}
+
+// Info logs a non-error message at the level mapped from v.
+func (s *logrSink) Info(v int, msg string, keysAndValues ...any) {
+	if logrLevel(v) == zapcore.DebugLevel {
+		s.l.Debug(context.Background(), msg, kvFields(keysAndValues)...)
+		return
+	}
+	s.l.Info(context.Background(), msg, kvFields(keysAndValues)...)
+}
+
+// Error logs an error message.
+func (s *logrSink) Error(err error, msg string, keysAndValues ...any) {
+	s.l.Error(context.Background(), msg, err, kvFields(keysAndValues)...)
+}
+
+// WithValues returns a sink with the key/value pairs attached.
+func (s *logrSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &logrSink{l: s.l.With(kvFields(keysAndValues)...)}
+}
+
+// WithName returns a sink for the named component.
+func (s *logrSink) WithName(name string) logr.LogSink {
+	return &logrSink{l: s.l.Named(name)}
+}
+
+// loggerEnabled reports whether l writes entries at lvl. Loggers other than
+// *Ion cannot be asked, so they report true and filter when writing.
+func loggerEnabled(l Logger, lvl zapcore.Level) bool {
+	if i, ok := l.(*Ion); ok && i.zapLogger != nil {
+		return i.enabled(lvl)
+	}
+	return true
+}
+
+// kvFields converts alternating keys and values to fields. A non-string key
+// is formatted with fmt; a key without a value gets a nil value.
+func kvFields(keysAndValues []any) []Field {
+	if len(keysAndValues) == 0 {
+		return nil
+	}
+	fields := make([]Field, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		var val any
+		if i+1 < len(keysAndValues) {
+			val = keysAndValues[i+1]
+		}
+		if _, isErr := val.(error); isErr {
+			// F would rename the key to "error".
+			fields = append(fields, Field{Key: key, Type: AnyType, Interface: val})
+			continue
+		}
+		fields = append(fields, F(key, val))
+	}
+	return fields
+}
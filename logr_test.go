@@ -0,0 +1,66 @@
+package ion
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogrSink(t *testing.T) {
+	app, logs := newSlogTestApp(zapcore.InfoLevel)
+	lr := NewLogr(app).WithName("controller").WithValues("reconciler", "validator")
+
+	lr.Info("reconciled", "node", "node-7", "attempt", 2)
+	lr.V(1).Info("dropped at info level")
+	lr.Error(errors.New("conflict"), "update failed", "cause", errors.New("stale"))
+
+	if lr.V(1).Enabled() {
+		t.Error("V(1).Enabled() = true at info level")
+	}
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+
+	info := entries[0]
+	if info.Level != zapcore.InfoLevel || info.LoggerName != "controller" {
+		t.Errorf("info entry = %v %q, want info from controller", info.Level, info.LoggerName)
+	}
+	fields := info.ContextMap()
+	if fields["reconciler"] != "validator" || fields["node"] != "node-7" || fields["attempt"] != int64(2) {
+		t.Errorf("info fields = %v", fields)
+	}
+
+	errEntry := entries[1]
+	fields = errEntry.ContextMap()
+	if errEntry.Level != zapcore.ErrorLevel || fields["error"] != "conflict" || fields["cause"] != "stale" {
+		t.Errorf("error entry = %v %v, want error with error and cause", errEntry.Level, fields)
+	}
+}
+
+func TestKVFields(t *testing.T) {
+	tests := []struct {
+		name string
+		kv   []any
+		want []string
+	}{
+		{"pairs", []any{"a", 1, "b", "x"}, []string{"a", "b"}},
+		{"non-string key", []any{42, "v"}, []string{"42"}},
+		{"dangling key", []any{"a", 1, "b"}, []string{"a", "b"}},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := kvFields(tt.kv)
+			if len(got) != len(tt.want) {
+				t.Fatalf("kvFields() = %d fields, want %d", len(got), len(tt.want))
+			}
+			for i, f := range got {
+				if f.Key != tt.want[i] {
+					t.Errorf("field %d key = %q, want %q", i, f.Key, tt.want[i])
+				}
+			}
+		})
+	}
+}
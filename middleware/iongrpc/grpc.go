@@ -6,20 +6,36 @@
 //	    grpc.StatsHandler(iongrpc.ServerHandler()),
 //	)
 //
+// Request IDs are echoed by the server interceptors:
+//
+//	server := grpc.NewServer(
+//	    grpc.StatsHandler(iongrpc.ServerHandler()),
+//	    grpc.ChainUnaryInterceptor(iongrpc.UnaryServerInterceptor()),
+//	)
+//
 // Client instrumentation using stats handler:
 //
 //	conn, err := grpc.Dial(addr,
 //	    grpc.WithStatsHandler(iongrpc.ClientHandler()),
 //	)
+//
+// gRPC's internal logs routed through ion:
+//
+//	grpclog.SetLoggerV2(iongrpc.NewLogger(app))
 package iongrpc
 
 import (
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/stats"
 )
 
 // ServerHandler returns a stats.Handler for gRPC server instrumentation.
 // Use with grpc.StatsHandler() option when creating a gRPC server.
+// The RPC context carries the incoming x-request-id, or a new ID if there is
+// none; UnaryServerInterceptor and StreamServerInterceptor echo it.
 //
 // Example:
 //
@@ -32,16 +48,16 @@ func ServerHandler(opts ...Option) stats.Handler {
 		opt.apply(o)
 	}
 
-	otelOpts := []otelgrpc.Option{}
-	if o.filter != nil {
-		otelOpts = append(otelOpts, otelgrpc.WithInterceptorFilter(o.filter)) //nolint:staticcheck // OpenTelemetry backward compatibility // Supporting legacy filter for now
+	h := otelgrpc.NewServerHandler(o.otelOptions()...)
+	if o.requestIDKey == "" && o.userIDKey == "" {
+		return h
 	}
-
-	return otelgrpc.NewServerHandler(otelOpts...)
+	return &idHandler{Handler: h, o: o, server: true}
 }
 
 // ClientHandler returns a stats.Handler for gRPC client instrumentation.
 // Use with grpc.WithStatsHandler() option when dialing.
+// Outgoing calls carry the request ID of their context.
 //
 // Example:
 //
@@ -54,22 +70,48 @@ func ClientHandler(opts ...Option) stats.Handler {
 		opt.apply(o)
 	}
 
-	otelOpts := []otelgrpc.Option{}
-	if o.filter != nil {
-		otelOpts = append(otelOpts, otelgrpc.WithInterceptorFilter(o.filter)) //nolint:staticcheck // OpenTelemetry backward compatibility // Supporting legacy filter for now
+	h := otelgrpc.NewClientHandler(o.otelOptions()...)
+	if o.requestIDKey == "" && o.userIDKey == "" {
+		return h
 	}
-
-	return otelgrpc.NewClientHandler(otelOpts...)
+	return &idHandler{Handler: h, o: o}
 }
 
 // --- Options ---
 
 type options struct {
-	filter otelgrpc.InterceptorFilter //nolint:staticcheck // OpenTelemetry backward compatibility
+	filter         otelgrpc.InterceptorFilter //nolint:staticcheck // OpenTelemetry backward compatibility
+	propagator     propagation.TextMapPropagator
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+
+	// Request and user ID metadata keys; empty disables.
+	requestIDKey string
+	userIDKey    string
 }
 
 func defaultOptions() *options {
-	return &options{}
+	return &options{
+		requestIDKey: DefaultRequestIDKey,
+	}
+}
+
+// otelOptions translates the collected options into otelgrpc options.
+func (o *options) otelOptions() []otelgrpc.Option {
+	otelOpts := []otelgrpc.Option{}
+	if o.filter != nil {
+		otelOpts = append(otelOpts, otelgrpc.WithInterceptorFilter(o.filter)) //nolint:staticcheck // OpenTelemetry backward compatibility // Supporting legacy filter for now
+	}
+	if o.propagator != nil {
+		otelOpts = append(otelOpts, otelgrpc.WithPropagators(o.propagator))
+	}
+	if o.tracerProvider != nil {
+		otelOpts = append(otelOpts, otelgrpc.WithTracerProvider(o.tracerProvider))
+	}
+	if o.meterProvider != nil {
+		otelOpts = append(otelOpts, otelgrpc.WithMeterProvider(o.meterProvider))
+	}
+	return otelOpts
 }
 
 // Option configures gRPC instrumentation.
@@ -94,3 +136,50 @@ func (f filterOption) apply(o *options) { o.filter = f.filter }
 func WithFilter(filter otelgrpc.InterceptorFilter) Option { //nolint:staticcheck // OpenTelemetry backward compatibility
 	return filterOption{filter: filter}
 }
+
+type propagatorOption struct {
+	propagator propagation.TextMapPropagator
+}
+
+func (p propagatorOption) apply(o *options) { o.propagator = p.propagator }
+
+// WithPropagators sets the propagator used to extract incoming and inject
+// outgoing trace metadata. Defaults to the global OTEL propagator.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.StatsHandler(iongrpc.ServerHandler(iongrpc.WithPropagators(app.Propagator()))))
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return propagatorOption{propagator: p}
+}
+
+type tracerProviderOption struct {
+	provider trace.TracerProvider
+}
+
+func (t tracerProviderOption) apply(o *options) { o.tracerProvider = t.provider }
+
+// WithTracerProvider sets the tracer provider spans are created with.
+// Defaults to the global OTEL tracer provider, which ion only installs when
+// Config.SetGlobalProviders is set, so pass the instance's provider:
+//
+//	grpc.NewServer(grpc.StatsHandler(iongrpc.ServerHandler(
+//	    iongrpc.WithTracerProvider(app.TracerProvider()),
+//	    iongrpc.WithPropagators(app.Propagator()),
+//	)))
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return tracerProviderOption{provider: tp}
+}
+
+type meterProviderOption struct {
+	provider metric.MeterProvider
+}
+
+func (m meterProviderOption) apply(o *options) { o.meterProvider = m.provider }
+
+// WithMeterProvider sets the meter provider request metrics are recorded
+// with. Defaults to the global OTEL meter provider; pass app.MeterProvider()
+// to record into the instance's metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return meterProviderOption{provider: mp}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/propagators/b3"
 )
 
 func TestServerHandler(t *testing.T) {
@@ -41,3 +42,13 @@ func TestClientHandler_WithFilter(t *testing.T) {
 		t.Fatal("expected non-nil client handler with filter")
 	}
 }
+
+func TestHandlers_WithPropagators(t *testing.T) {
+	// Test that WithPropagators option is accepted on both sides
+	if ServerHandler(WithPropagators(b3.New())) == nil {
+		t.Fatal("expected non-nil server handler with propagators")
+	}
+	if ClientHandler(WithPropagators(b3.New())) == nil {
+		t.Fatal("expected non-nil client handler with propagators")
+	}
+}
@@ -0,0 +1,119 @@
+package iongrpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/grpclog"
+
+	"github.com/JupiterMetaLabs/ion"
+)
+
+// NewLogger returns a grpclog.LoggerV2 that writes gRPC's internal logs to l
+// under the name "grpc". gRPC component prefixes such as "[transport]" become
+// child names ("grpc.transport"), so per-component levels apply to them.
+//
+// gRPC levels map as: Info to debug, Warning to warn, Error to error, and
+// Fatal to critical. Fatal does not exit here; grpclog.Fatal exits after
+// calling it. V(n) reports n <= the verbosity set by WithVerbosity (default 0).
+//
+// Install it before any other gRPC call:
+//
+//	grpclog.SetLoggerV2(iongrpc.NewLogger(app))
+func NewLogger(l ion.Logger, opts ...LoggerOption) grpclog.LoggerV2 {
+	o := &loggerOptions{}
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+	return &grpcLogger{root: l.Named("grpc"), verbosity: o.verbosity}
+}
+
+// grpcLogger adapts an ion.Logger to grpclog.LoggerV2.
+type grpcLogger struct {
+	root       ion.Logger
+	verbosity  int
+	components sync.Map // component name -> ion.Logger
+}
+
+// split strips a leading "[component] " prefix from msg and returns the
+// logger for that component.
+func (g *grpcLogger) split(msg string) (ion.Logger, string) {
+	if !strings.HasPrefix(msg, "[") {
+		return g.root, msg
+	}
+	name, rest, ok := strings.Cut(msg[1:], "] ")
+	if !ok || name == "" || strings.ContainsAny(name, " []") {
+		return g.root, msg
+	}
+	if l, ok := g.components.Load(name); ok {
+		return l.(ion.Logger), rest
+	}
+	l, _ := g.components.LoadOrStore(name, g.root.Named(name))
+	return l.(ion.Logger), rest
+}
+
+func (g *grpcLogger) info(msg string) {
+	l, msg := g.split(msg)
+	l.Debug(context.Background(), msg)
+}
+
+func (g *grpcLogger) warning(msg string) {
+	l, msg := g.split(msg)
+	l.Warn(context.Background(), msg)
+}
+
+func (g *grpcLogger) error(msg string) {
+	l, msg := g.split(msg)
+	l.Error(context.Background(), msg, nil)
+}
+
+func (g *grpcLogger) fatal(msg string) {
+	l, msg := g.split(msg)
+	l.Critical(context.Background(), msg, nil)
+}
+
+// sprintln formats like fmt.Sprintln without the trailing newline.
+func sprintln(args []any) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
+
+func (g *grpcLogger) Info(args ...any)                    { g.info(fmt.Sprint(args...)) }
+func (g *grpcLogger) Infoln(args ...any)                  { g.info(sprintln(args)) }
+func (g *grpcLogger) Infof(format string, args ...any)    { g.info(fmt.Sprintf(format, args...)) }
+func (g *grpcLogger) Warning(args ...any)                 { g.warning(fmt.Sprint(args...)) }
+func (g *grpcLogger) Warningln(args ...any)               { g.warning(sprintln(args)) }
+func (g *grpcLogger) Warningf(format string, args ...any) { g.warning(fmt.Sprintf(format, args...)) }
+func (g *grpcLogger) Error(args ...any)                   { g.error(fmt.Sprint(args...)) }
+func (g *grpcLogger) Errorln(args ...any)                 { g.error(sprintln(args)) }
+func (g *grpcLogger) Errorf(format string, args ...any)   { g.error(fmt.Sprintf(format, args...)) }
+func (g *grpcLogger) Fatal(args ...any)                   { g.fatal(fmt.Sprint(args...)) }
+func (g *grpcLogger) Fatalln(args ...any)                 { g.fatal(sprintln(args)) }
+func (g *grpcLogger) Fatalf(format string, args ...any)   { g.fatal(fmt.Sprintf(format, args...)) }
+
+// V reports whether verbosity level l is enabled.
+func (g *grpcLogger) V(l int) bool { return l <= g.verbosity }
+
+// --- Logger options ---
+
+type loggerOptions struct {
+	verbosity int
+}
+
+// LoggerOption configures NewLogger.
+type LoggerOption interface {
+	apply(*loggerOptions)
+}
+
+type verbosityOption struct {
+	verbosity int
+}
+
+func (v verbosityOption) apply(o *loggerOptions) { o.verbosity = v.verbosity }
+
+// WithVerbosity sets the highest gRPC V-level that is logged, like
+// GRPC_GO_LOG_VERBOSITY_LEVEL. Default: 0.
+func WithVerbosity(n int) LoggerOption {
+	return verbosityOption{verbosity: n}
+}
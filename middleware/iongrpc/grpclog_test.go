@@ -0,0 +1,81 @@
+package iongrpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/JupiterMetaLabs/ion"
+)
+
+type logEntry struct {
+	level, name, msg string
+}
+
+// recordingLogger is an ion.Logger that records entries.
+type recordingLogger struct {
+	name    string
+	mu      *sync.Mutex
+	entries *[]logEntry
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{mu: new(sync.Mutex), entries: new([]logEntry)}
+}
+
+func (r *recordingLogger) add(level, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.entries = append(*r.entries, logEntry{level, r.name, msg})
+}
+
+func (r *recordingLogger) Debug(_ context.Context, msg string, _ ...ion.Field) { r.add("debug", msg) }
+func (r *recordingLogger) Info(_ context.Context, msg string, _ ...ion.Field)  { r.add("info", msg) }
+func (r *recordingLogger) Warn(_ context.Context, msg string, _ ...ion.Field)  { r.add("warn", msg) }
+func (r *recordingLogger) Error(_ context.Context, msg string, _ error, _ ...ion.Field) {
+	r.add("error", msg)
+}
+func (r *recordingLogger) Critical(_ context.Context, msg string, _ error, _ ...ion.Field) {
+	r.add("critical", msg)
+}
+func (r *recordingLogger) With(...ion.Field) ion.Logger { return r }
+func (r *recordingLogger) Named(name string) ion.Logger {
+	if r.name != "" {
+		name = r.name + "." + name
+	}
+	return &recordingLogger{name: name, mu: r.mu, entries: r.entries}
+}
+func (r *recordingLogger) Sync() error                    { return nil }
+func (r *recordingLogger) Shutdown(context.Context) error { return nil }
+func (r *recordingLogger) SetLevel(string)                {}
+func (r *recordingLogger) GetLevel() string               { return "debug" }
+
+func TestNewLogger(t *testing.T) {
+	rec := newRecordingLogger()
+	l := NewLogger(rec, WithVerbosity(2))
+
+	l.Infof("[transport] http2 server: %s", "closing")
+	l.Warningln("[core]", "channel idle")
+	l.Error("plain error")
+	l.Fatal("[balancer] no backends")
+
+	want := []logEntry{
+		{"debug", "grpc.transport", "http2 server: closing"},
+		{"warn", "grpc.core", "channel idle"},
+		{"error", "grpc", "plain error"},
+		{"critical", "grpc.balancer", "no backends"},
+	}
+	got := *rec.entries
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if !l.V(2) || l.V(3) {
+		t.Errorf("V(2), V(3) = %v, %v, want true, false", l.V(2), l.V(3))
+	}
+}
@@ -0,0 +1,175 @@
+package iongrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+
+	"github.com/JupiterMetaLabs/ion"
+	"github.com/JupiterMetaLabs/ion/internal/core"
+)
+
+// DefaultRequestIDKey carries the request ID between services.
+const DefaultRequestIDKey = "x-request-id"
+
+// idHandler wraps an otelgrpc stats.Handler to carry request and user IDs:
+// on the server it puts the incoming IDs in the RPC context, on the client
+// it adds the context's IDs to the outgoing metadata.
+type idHandler struct {
+	stats.Handler
+	o      *options
+	server bool
+}
+
+func (h *idHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	if h.server {
+		ctx = h.o.incomingIDs(ctx)
+	} else {
+		ctx = h.o.outgoingIDs(ctx)
+	}
+	return h.Handler.TagRPC(ctx, info)
+}
+
+// incomingIDs returns ctx with the request ID from the incoming metadata,
+// or a new one if it is missing or invalid, and the incoming user ID when
+// WithUserIDKey is set.
+func (o *options) incomingIDs(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if o.requestIDKey != "" {
+		id := first(md, o.requestIDKey)
+		if !core.ValidRequestID(id) {
+			id = ion.NewRequestID()
+		}
+		ctx = ion.WithRequestID(ctx, id)
+	}
+	if o.userIDKey != "" {
+		if id := first(md, o.userIDKey); core.ValidRequestID(id) {
+			ctx = ion.WithUserID(ctx, id)
+		}
+	}
+	return ctx
+}
+
+// outgoingIDs returns ctx with its request and user IDs added to the
+// outgoing metadata, unless the caller already set them.
+func (o *options) outgoingIDs(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	var kv []string
+	if o.requestIDKey != "" && first(md, o.requestIDKey) == "" {
+		if id := ion.RequestIDFromContext(ctx); id != "" {
+			kv = append(kv, o.requestIDKey, id)
+		}
+	}
+	if o.userIDKey != "" && first(md, o.userIDKey) == "" {
+		if id := ion.UserIDFromContext(ctx); id != "" {
+			kv = append(kv, o.userIDKey, id)
+		}
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// serverIDs returns the RPC context with its request ID, taking it from
+// the metadata when ServerHandler is not installed, and the response header
+// echoing it.
+func (o *options) serverIDs(ctx context.Context) (context.Context, metadata.MD) {
+	if o.requestIDKey == "" {
+		return ctx, nil
+	}
+	if ion.RequestIDFromContext(ctx) == "" {
+		ctx = o.incomingIDs(ctx)
+	}
+	return ctx, metadata.Pairs(o.requestIDKey, ion.RequestIDFromContext(ctx))
+}
+
+// UnaryServerInterceptor echoes the request ID in the response header.
+// Stats handlers run before gRPC can send headers, so ServerHandler only
+// puts the ID in the context; install this interceptor as well:
+//
+//	grpc.NewServer(
+//	    grpc.StatsHandler(iongrpc.ServerHandler()),
+//	    grpc.ChainUnaryInterceptor(iongrpc.UnaryServerInterceptor()),
+//	    grpc.ChainStreamInterceptor(iongrpc.StreamServerInterceptor()),
+//	)
+//
+// Without ServerHandler it reads or generates the ID itself.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, md := o.serverIDs(ctx)
+		if md != nil {
+			_ = grpc.SetHeader(ctx, md)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, md := o.serverIDs(ss.Context())
+		if md != nil {
+			_ = ss.SetHeader(md)
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// --- ID options ---
+
+type requestIDKeyOption struct {
+	key string
+}
+
+func (r requestIDKeyOption) apply(o *options) { o.requestIDKey = r.key }
+
+// WithRequestIDKey sets the metadata key the request ID is read from,
+// echoed in, and sent with. Default: DefaultRequestIDKey. An empty key turns
+// request ID handling off.
+func WithRequestIDKey(key string) Option {
+	return requestIDKeyOption{key: key}
+}
+
+type userIDKeyOption struct {
+	key string
+}
+
+func (u userIDKeyOption) apply(o *options) { o.userIDKey = u.key }
+
+// WithUserIDKey turns on user ID propagation: ServerHandler puts the value
+// of the metadata key in the RPC context with ion.WithUserID, and
+// ClientHandler sends the context's user ID under it. Off by default, since
+// the incoming value is not verified and outgoing calls may reach third
+// parties; use it only between trusted services.
+func WithUserIDKey(key string) Option {
+	return userIDKeyOption{key: key}
+}
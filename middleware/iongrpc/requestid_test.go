@@ -0,0 +1,101 @@
+package iongrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/JupiterMetaLabs/ion"
+)
+
+// startHealthServer serves the gRPC health service over an in-memory
+// listener with ion's handlers, recording each RPC's request and user ID.
+func startHealthServer(t *testing.T, opts ...Option) (healthpb.HealthClient, *[2]string) {
+	t.Helper()
+	got := new([2]string)
+	record := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		got[0], got[1] = ion.RequestIDFromContext(ctx), ion.UserIDFromContext(ctx)
+		return handler(ctx, req)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.StatsHandler(ServerHandler(opts...)),
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(opts...), record),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(ClientHandler(opts...)),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return healthpb.NewHealthClient(conn), got
+}
+
+func TestHandlers_RequestID(t *testing.T) {
+	client, got := startHealthServer(t)
+
+	ctx := ion.WithUserID(ion.WithRequestID(context.Background(), "req-42"), "alice")
+	var header metadata.MD
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check() error: %v", err)
+	}
+	if got[0] != "req-42" || got[1] != "" {
+		t.Errorf("server saw request ID %q, user ID %q, want req-42 and no user ID by default", got[0], got[1])
+	}
+	if echoed := first(header, DefaultRequestIDKey); echoed != "req-42" {
+		t.Errorf("echoed request ID = %q, want req-42", echoed)
+	}
+
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check() error: %v", err)
+	}
+	if len(got[0]) != 26 || got[1] != "" {
+		t.Errorf("server saw request ID %q, user ID %q, want a generated ULID and none", got[0], got[1])
+	}
+	if echoed := first(header, DefaultRequestIDKey); echoed != got[0] {
+		t.Errorf("echoed request ID = %q, want %q", echoed, got[0])
+	}
+}
+
+func TestHandlers_RequestIDKey(t *testing.T) {
+	client, got := startHealthServer(t, WithRequestIDKey("x-correlation-id"), WithUserIDKey("x-user-id"))
+
+	ctx := ion.WithUserID(ion.WithRequestID(context.Background(), "corr-1"), "alice")
+	var header metadata.MD
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check() error: %v", err)
+	}
+	if got[0] != "corr-1" || got[1] != "alice" {
+		t.Errorf("server saw request ID %q, user ID %q, want corr-1, alice", got[0], got[1])
+	}
+	if echoed := first(header, "x-correlation-id"); echoed != "corr-1" {
+		t.Errorf("echoed request ID = %q, want corr-1", echoed)
+	}
+}
+
+func TestClientHandler_UserIDOffByDefault(t *testing.T) {
+	ctx := ion.WithUserID(ion.WithRequestID(context.Background(), "req-42"), "alice")
+	md, _ := metadata.FromOutgoingContext(defaultOptions().outgoingIDs(ctx))
+
+	if got := first(md, DefaultRequestIDKey); got != "req-42" {
+		t.Errorf("outgoing request ID = %q, want req-42", got)
+	}
+	if got := md.Get("x-user-id"); len(got) != 0 {
+		t.Errorf("default client sends user ID %v, want none", got)
+	}
+}
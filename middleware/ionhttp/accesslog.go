@@ -0,0 +1,186 @@
+package ionhttp
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/JupiterMetaLabs/ion"
+)
+
+// accessLog wraps next so each request it serves is logged through
+// o.logger once the response is written. It runs inside the otelhttp handler, so the
+// request context carries the server span and the entry gets its
+// trace_id/span_id.
+func accessLog(next http.Handler, o *options) http.Handler {
+	l := o.logger.Named("http")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, skip := o.skipPaths[r.URL.Path]; skip {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+		elapsed := time.Since(start)
+
+		status := rw.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		level := o.accessLevel(status)
+		fields := []ion.Field{
+			ion.String("method", r.Method),
+			ion.String("route", r.Pattern),
+			ion.String("path", r.URL.Path),
+			ion.Int("status", status),
+			ion.Int64("bytes", rw.bytes),
+			ion.Duration("duration", elapsed),
+			ion.String("remote_addr", r.RemoteAddr),
+		}
+		if o.slowThreshold > 0 && elapsed >= o.slowThreshold {
+			fields = append(fields, ion.Bool("slow", true))
+			if level == "debug" || level == "info" {
+				level = "warn"
+			}
+		}
+		logAt(r, l, level, fields)
+	})
+}
+
+// logAt writes the access-log entry at level.
+func logAt(r *http.Request, l ion.Logger, level string, fields []ion.Field) {
+	ctx := r.Context()
+	const msg = "http request"
+	switch level {
+	case "debug":
+		l.Debug(ctx, msg, fields...)
+	case "warn":
+		l.Warn(ctx, msg, fields...)
+	case "error":
+		l.Error(ctx, msg, nil, fields...)
+	case "critical":
+		l.Critical(ctx, msg, nil, fields...)
+	default:
+		l.Info(ctx, msg, fields...)
+	}
+}
+
+// defaultAccessLevel logs 5xx responses at error, 4xx at warn, and the rest
+// at info.
+func defaultAccessLevel(status int) string {
+	switch {
+	case status >= 500:
+		return "error"
+	case status >= 400:
+		return "warn"
+	default:
+		return "info"
+	}
+}
+
+// responseWriter records the status code and body size of a response.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher for streaming handlers.
+func (w *responseWriter) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// hijack the connection or set deadlines.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// --- Access log options ---
+
+type loggerOption struct {
+	logger ion.Logger
+}
+
+func (l loggerOption) apply(o *options) { o.logger = l.logger }
+
+// WithLogger makes Handler write one access-log entry per request to l,
+// named "http", with the method, route pattern, path, status, bytes written,
+// duration, and remote address. The entry is logged with the request
+// context, so it carries the request's trace_id and span_id. Client and
+// Transport ignore it.
+//
+// Example:
+//
+//	ionhttp.Handler(mux, "api",
+//	    ionhttp.WithLogger(app),
+//	    ionhttp.WithSkipPaths("/health"),
+//	    ionhttp.WithSlowThreshold(time.Second),
+//	)
+func WithLogger(l ion.Logger) Option {
+	return loggerOption{logger: l}
+}
+
+type accessLevelOption struct {
+	level func(status int) string
+}
+
+func (a accessLevelOption) apply(o *options) {
+	if a.level != nil {
+		o.accessLevel = a.level
+	}
+}
+
+// WithAccessLevel sets the level ("debug", "info", "warn", "error", or
+// "critical") each access-log entry is written at, by response status.
+// Default: 5xx at error, 4xx at warn, everything else at info.
+func WithAccessLevel(level func(status int) string) Option {
+	return accessLevelOption{level: level}
+}
+
+type skipPathsOption struct {
+	paths []string
+}
+
+func (s skipPathsOption) apply(o *options) {
+	for _, p := range s.paths {
+		o.skipPaths[p] = struct{}{}
+	}
+}
+
+// WithSkipPaths excludes requests whose URL path equals one of paths from
+// the access log, e.g. health checks. They are still traced; use WithFilter
+// to skip tracing.
+func WithSkipPaths(paths ...string) Option {
+	return skipPathsOption{paths: paths}
+}
+
+type slowThresholdOption struct {
+	threshold time.Duration
+}
+
+func (s slowThresholdOption) apply(o *options) { o.slowThreshold = s.threshold }
+
+// WithSlowThreshold marks requests taking at least d with slow=true and logs
+// them at warn or above, whatever their status. Zero (the default) disables
+// it.
+func WithSlowThreshold(d time.Duration) Option {
+	return slowThresholdOption{threshold: d}
+}
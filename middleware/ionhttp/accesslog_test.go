@@ -0,0 +1,102 @@
+package ionhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/ion"
+	"github.com/JupiterMetaLabs/ion/iontest"
+)
+
+func TestHandler_WithLogger(t *testing.T) {
+	app, obs := iontest.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tx/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("accepted"))
+	})
+	mux.HandleFunc("GET /missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no", http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /fail", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {})
+	handler := Handler(mux, "api",
+		WithLogger(app),
+		WithTracerProvider(app.TracerProvider()),
+		WithSkipPaths("/health"),
+	)
+
+	for _, path := range []string{"/tx/42", "/missing", "/fail", "/health"} {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = "10.0.0.1:5000"
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	tests := []struct {
+		level  string
+		fields []ion.Field
+	}{
+		{"info", []ion.Field{
+			ion.String("method", "GET"),
+			ion.String("route", "GET /tx/{id}"),
+			ion.String("path", "/tx/42"),
+			ion.Int("status", 200),
+			ion.Int64("bytes", 8),
+			ion.String("remote_addr", "10.0.0.1:5000"),
+		}},
+		{"warn", []ion.Field{ion.String("path", "/missing"), ion.Int("status", 404)}},
+		{"error", []ion.Field{ion.String("path", "/fail"), ion.Int("status", 502)}},
+	}
+	for _, tt := range tests {
+		obs.AssertLogged(t, tt.level, "http request", tt.fields...)
+	}
+
+	if n := obs.FilterField(ion.String("path", "/health")).Len(); n != 0 {
+		t.Errorf("logged %d entries for a skipped path, want 0", n)
+	}
+
+	span := obs.FindSpan("api")
+	if span == nil {
+		t.Fatal("no server span recorded")
+	}
+	obs.AssertLogged(t, "info", "http request",
+		ion.String("path", "/tx/42"),
+		ion.String("trace_id", span.SpanContext().TraceID().String()),
+		ion.String("span_id", span.SpanContext().SpanID().String()),
+	)
+}
+
+func TestHandler_AccessLogLevels(t *testing.T) {
+	app, obs := iontest.New(t)
+
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	handler := Handler(inner, "api",
+		WithLogger(app),
+		WithSlowThreshold(10*time.Millisecond),
+		WithAccessLevel(func(status int) string {
+			if status == http.StatusNotFound {
+				return "debug"
+			}
+			return "info"
+		}),
+	)
+
+	for _, path := range []string{"/fast", "/slow"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	obs.AssertLogged(t, "debug", "http request", ion.String("path", "/fast"))
+	obs.AssertLogged(t, "warn", "http request", ion.String("path", "/slow"), ion.Bool("slow", true))
+	if n := obs.FilterField(ion.Bool("slow", true)).Len(); n != 1 {
+		t.Errorf("%d entries marked slow, want 1", n)
+	}
+}
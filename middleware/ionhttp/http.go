@@ -7,6 +7,10 @@
 //	instrumented := ionhttp.Handler(mux, "my-service")
 //	http.ListenAndServe(":8080", instrumented)
 //
+// WithLogger adds a structured access log, one entry per request:
+//
+//	instrumented := ionhttp.Handler(mux, "my-service", ionhttp.WithLogger(app))
+//
 // Client instrumentation wraps an http.Client:
 //
 //	client := ionhttp.Client()
@@ -15,8 +19,14 @@ package ionhttp
 
 import (
 	"net/http"
+	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/JupiterMetaLabs/ion"
 )
 
 // Handler wraps an http.Handler with OpenTelemetry instrumentation.
@@ -25,49 +35,93 @@ import (
 // - URL path
 // - Status code
 // - Request/response size
+//
+// The request context carries the incoming X-Request-ID, or a new ID if
+// there is none, which is echoed in the response; see WithRequestIDHeader.
+// With WithLogger, each request is also written to the access log.
 func Handler(handler http.Handler, operation string, opts ...Option) http.Handler {
 	o := defaultOptions()
 	for _, opt := range opts {
 		opt.apply(o)
 	}
 
-	otelOpts := []otelhttp.Option{}
-	if o.filter != nil {
-		otelOpts = append(otelOpts, otelhttp.WithFilter(o.filter))
+	if o.logger != nil {
+		handler = accessLog(handler, o)
 	}
-
-	return otelhttp.NewHandler(handler, operation, otelOpts...)
+	if o.requestIDHeader != "" || o.userIDHeader != "" {
+		handler = requestIDs(handler, o)
+	}
+	return otelhttp.NewHandler(handler, operation, o.otelOptions()...)
 }
 
 // Client returns an HTTP client instrumented with OpenTelemetry.
-// Each request creates a client span linked to the current trace context.
+// Each request creates a client span linked to the current trace context
+// and carries the context's request ID.
 func Client(opts ...Option) *http.Client {
-	o := defaultOptions()
-	for _, opt := range opts {
-		opt.apply(o)
-	}
-
-	transport := otelhttp.NewTransport(http.DefaultTransport)
-	return &http.Client{Transport: transport}
+	return &http.Client{Transport: Transport(http.DefaultTransport, opts...)}
 }
 
 // Transport returns an http.RoundTripper instrumented with OpenTelemetry.
 // Use this to instrument custom transports.
-func Transport(base http.RoundTripper) http.RoundTripper {
+func Transport(base http.RoundTripper, opts ...Option) http.RoundTripper {
 	if base == nil {
 		base = http.DefaultTransport
 	}
-	return otelhttp.NewTransport(base)
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+
+	if o.requestIDHeader != "" || o.userIDHeader != "" {
+		base = &idTransport{base: base, o: o}
+	}
+	return otelhttp.NewTransport(base, o.otelOptions()...)
 }
 
 // --- Options ---
 
 type options struct {
-	filter otelhttp.Filter
+	filter         otelhttp.Filter
+	propagator     propagation.TextMapPropagator
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+
+	// Request and user ID headers; empty disables.
+	requestIDHeader string
+	userIDHeader    string
+
+	// Access log (Handler only).
+	logger        ion.Logger
+	accessLevel   func(status int) string
+	skipPaths     map[string]struct{}
+	slowThreshold time.Duration
 }
 
 func defaultOptions() *options {
-	return &options{}
+	return &options{
+		requestIDHeader: DefaultRequestIDHeader,
+		accessLevel:     defaultAccessLevel,
+		skipPaths:       map[string]struct{}{},
+	}
+}
+
+// otelOptions translates the collected options into otelhttp options.
+func (o *options) otelOptions() []otelhttp.Option {
+	otelOpts := []otelhttp.Option{}
+	if o.filter != nil {
+		otelOpts = append(otelOpts, otelhttp.WithFilter(o.filter))
+	}
+	if o.propagator != nil {
+		otelOpts = append(otelOpts, otelhttp.WithPropagators(o.propagator))
+	}
+	if o.tracerProvider != nil {
+		otelOpts = append(otelOpts, otelhttp.WithTracerProvider(o.tracerProvider))
+	}
+	if o.meterProvider != nil {
+		otelOpts = append(otelOpts, otelhttp.WithMeterProvider(o.meterProvider))
+	}
+	return otelOpts
 }
 
 // Option configures HTTP instrumentation.
@@ -92,3 +146,50 @@ func (f filterOption) apply(o *options) { o.filter = f.filter }
 func WithFilter(filter func(r *http.Request) bool) Option {
 	return filterOption{filter: otelhttp.Filter(filter)}
 }
+
+type propagatorOption struct {
+	propagator propagation.TextMapPropagator
+}
+
+func (p propagatorOption) apply(o *options) { o.propagator = p.propagator }
+
+// WithPropagators sets the propagator used to extract incoming and inject
+// outgoing trace headers. Defaults to the global OTEL propagator.
+//
+// Example:
+//
+//	ionhttp.Handler(mux, "api", ionhttp.WithPropagators(app.Propagator()))
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return propagatorOption{propagator: p}
+}
+
+type tracerProviderOption struct {
+	provider trace.TracerProvider
+}
+
+func (t tracerProviderOption) apply(o *options) { o.tracerProvider = t.provider }
+
+// WithTracerProvider sets the tracer provider spans are created with.
+// Defaults to the global OTEL tracer provider, which ion only installs when
+// Config.SetGlobalProviders is set, so pass the instance's provider:
+//
+//	ionhttp.Handler(mux, "api",
+//	    ionhttp.WithTracerProvider(app.TracerProvider()),
+//	    ionhttp.WithPropagators(app.Propagator()),
+//	)
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return tracerProviderOption{provider: tp}
+}
+
+type meterProviderOption struct {
+	provider metric.MeterProvider
+}
+
+func (m meterProviderOption) apply(o *options) { o.meterProvider = m.provider }
+
+// WithMeterProvider sets the meter provider request metrics are recorded
+// with. Defaults to the global OTEL meter provider; pass app.MeterProvider()
+// to record into the instance's metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return meterProviderOption{provider: mp}
+}
@@ -1,9 +1,15 @@
 package ionhttp
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestHandler(t *testing.T) {
@@ -99,3 +105,62 @@ func TestTransport(t *testing.T) {
 		t.Errorf("expected status 200, got %d", resp.StatusCode)
 	}
 }
+
+func TestHandler_WithPropagators(t *testing.T) {
+	const traceID = "463ac35c9f6413ad48485a3953bb6124"
+
+	var got string
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = trace.SpanContextFromContext(r.Context()).TraceID().String()
+	})
+	handler := Handler(inner, "api", WithPropagators(b3.New()))
+
+	req := httptest.NewRequest("GET", "/api", nil)
+	req.Header.Set("b3", traceID+"-0020000000000001-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != traceID {
+		t.Errorf("extracted trace ID = %q, want %q", got, traceID)
+	}
+}
+
+func TestTransport_WithPropagators(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("b3")
+	}))
+	defer server.Close()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	client := &http.Client{Transport: Transport(nil, WithPropagators(b3.New(b3.WithInjectEncoding(b3.B3SingleHeader))))}
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got == "" {
+		t.Error("expected b3 header to be injected")
+	}
+}
+
+func TestHandler_WithTracerProvider(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	handler := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), "api", WithTracerProvider(tp))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api", nil))
+
+	if n := len(sr.Ended()); n != 1 {
+		t.Errorf("recorded %d spans on the given provider, want 1", n)
+	}
+}
@@ -0,0 +1,97 @@
+package ionhttp
+
+import (
+	"net/http"
+
+	"github.com/JupiterMetaLabs/ion"
+	"github.com/JupiterMetaLabs/ion/internal/core"
+)
+
+// DefaultRequestIDHeader carries the request ID between services.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// requestIDs wraps next so each request carries a request ID in its context:
+// the incoming one if valid, otherwise a new one. The ID is echoed in the
+// response header. With WithUserIDHeader, the incoming user ID is put in the
+// context as well.
+func requestIDs(next http.Handler, o *options) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if o.requestIDHeader != "" {
+			id := r.Header.Get(o.requestIDHeader)
+			if !core.ValidRequestID(id) {
+				id = ion.NewRequestID()
+			}
+			w.Header().Set(o.requestIDHeader, id)
+			ctx = ion.WithRequestID(ctx, id)
+		}
+		if o.userIDHeader != "" {
+			if id := r.Header.Get(o.userIDHeader); core.ValidRequestID(id) {
+				ctx = ion.WithUserID(ctx, id)
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// idTransport sets the request and user ID headers on outgoing requests
+// from the IDs in their context, unless already set.
+type idTransport struct {
+	base http.RoundTripper
+	o    *options
+}
+
+func (t *idTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	set := map[string]string{}
+	if h := t.o.requestIDHeader; h != "" && req.Header.Get(h) == "" {
+		if id := ion.RequestIDFromContext(ctx); id != "" {
+			set[h] = id
+		}
+	}
+	if h := t.o.userIDHeader; h != "" && req.Header.Get(h) == "" {
+		if id := ion.UserIDFromContext(ctx); id != "" {
+			set[h] = id
+		}
+	}
+	if len(set) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(ctx)
+	for h, v := range set {
+		req.Header.Set(h, v)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// --- ID options ---
+
+type requestIDHeaderOption struct {
+	header string
+}
+
+func (r requestIDHeaderOption) apply(o *options) { o.requestIDHeader = r.header }
+
+// WithRequestIDHeader sets the header the request ID is read from, echoed
+// in, and sent with. Default: DefaultRequestIDHeader. An empty name turns
+// request ID handling off.
+func WithRequestIDHeader(name string) Option {
+	return requestIDHeaderOption{header: name}
+}
+
+type userIDHeaderOption struct {
+	header string
+}
+
+func (u userIDHeaderOption) apply(o *options) { o.userIDHeader = u.header }
+
+// WithUserIDHeader turns on user ID propagation: Handler puts the value of
+// the header in the request context with ion.WithUserID, and Client and
+// Transport send the context's user ID in it. Off by default, since the
+// incoming value is not verified and outgoing calls may reach third parties;
+// use it only between trusted services.
+func WithUserIDHeader(name string) Option {
+	return userIDHeaderOption{header: name}
+}
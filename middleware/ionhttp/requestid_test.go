@@ -0,0 +1,134 @@
+package ionhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JupiterMetaLabs/ion"
+)
+
+func TestHandler_RequestID(t *testing.T) {
+	var gotRequestID, gotUserID string
+	handler := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequestID = ion.RequestIDFromContext(r.Context())
+		gotUserID = ion.UserIDFromContext(r.Context())
+	}), "api", WithUserIDHeader("X-User-ID"))
+
+	tests := []struct {
+		name      string
+		requestID string
+		userID    string
+		generated bool
+	}{
+		{"incoming", "req-42", "alice", false},
+		{"missing", "", "", true},
+		{"invalid", "bad id\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api", nil)
+			if tt.requestID != "" {
+				req.Header.Set("X-Request-ID", tt.requestID)
+			}
+			if tt.userID != "" {
+				req.Header.Set("X-User-ID", tt.userID)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if tt.generated {
+				if len(gotRequestID) != 26 {
+					t.Errorf("generated request ID = %q, want a 26-character ULID", gotRequestID)
+				}
+			} else if gotRequestID != tt.requestID {
+				t.Errorf("request ID = %q, want %q", gotRequestID, tt.requestID)
+			}
+			if echoed := rec.Header().Get("X-Request-ID"); echoed != gotRequestID {
+				t.Errorf("echoed request ID = %q, want %q", echoed, gotRequestID)
+			}
+			if gotUserID != tt.userID {
+				t.Errorf("user ID = %q, want %q", gotUserID, tt.userID)
+			}
+		})
+	}
+}
+
+func TestHandler_RequestIDHeader(t *testing.T) {
+	var got string
+	handler := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ion.RequestIDFromContext(r.Context())
+	}), "api", WithRequestIDHeader("X-Correlation-ID"))
+
+	req := httptest.NewRequest("GET", "/api", nil)
+	req.Header.Set("X-Correlation-ID", "corr-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got != "corr-1" || rec.Header().Get("X-Correlation-ID") != "corr-1" {
+		t.Errorf("request ID = %q, echoed %q, want corr-1", got, rec.Header().Get("X-Correlation-ID"))
+	}
+
+	handler = Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ion.RequestIDFromContext(r.Context())
+	}), "api", WithRequestIDHeader(""))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api", nil))
+	if got != "" || rec.Header().Get("X-Request-ID") != "" {
+		t.Errorf("disabled: request ID = %q, echoed %q, want none", got, rec.Header().Get("X-Request-ID"))
+	}
+}
+
+func TestHandler_UserIDOffByDefault(t *testing.T) {
+	var got string
+	handler := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ion.UserIDFromContext(r.Context())
+	}), "api")
+
+	req := httptest.NewRequest("GET", "/api", nil)
+	req.Header.Set("X-User-ID", "mallory")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "" {
+		t.Errorf("user ID = %q from an unverified header, want none by default", got)
+	}
+}
+
+func TestTransport_RequestID(t *testing.T) {
+	var gotRequestID, gotUserID string
+	var hasUserID bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequestID = r.Header.Get("X-Request-ID")
+		gotUserID = r.Header.Get("X-User-ID")
+		_, hasUserID = r.Header["X-User-Id"]
+	}))
+	defer server.Close()
+
+	ctx := ion.WithUserID(ion.WithRequestID(context.Background(), "req-42"), "alice")
+	send := func(client *http.Client) {
+		t.Helper()
+		req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		_ = resp.Body.Close()
+		if req.Header.Get("X-Request-ID") != "" {
+			t.Error("transport modified the caller's request")
+		}
+	}
+
+	send(Client())
+	if gotRequestID != "req-42" {
+		t.Errorf("sent request ID %q, want req-42", gotRequestID)
+	}
+	if hasUserID {
+		t.Errorf("default client sent user ID %q, want no header", gotUserID)
+	}
+
+	send(Client(WithUserIDHeader("X-User-ID")))
+	if gotUserID != "alice" {
+		t.Errorf("sent user ID %q with WithUserIDHeader, want alice", gotUserID)
+	}
+}
@@ -0,0 +1,123 @@
+package ion
+
+import (
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/JupiterMetaLabs/ion/internal/core"
+)
+
+// Option configures [New] with components that Config cannot express.
+// ion manages their lifecycle: [Ion.Shutdown] shuts down span processors and
+// exporters first, then metric readers, then log exporters, and finally syncs
+// the zap cores, so telemetry emitted while draining is still written.
+type Option interface {
+	apply(*newOptions)
+}
+
+type newOptions struct {
+	components core.Components
+	extractors []ContextExtractor
+}
+
+type zapCoreOption struct{ core zapcore.Core }
+
+func (o zapCoreOption) apply(n *newOptions) {
+	n.components.ZapCores = append(n.components.ZapCores, o.core)
+}
+
+// WithZapCore adds c as an extra log output. Entries reach it through the
+// same level, redaction, and sampling rules as the built-in outputs; its
+// per-output level is set with SetOutputLevel("custom", ...).
+func WithZapCore(c zapcore.Core) Option {
+	return zapCoreOption{core: c}
+}
+
+type spanProcessorOption struct{ processor sdktrace.SpanProcessor }
+
+func (o spanProcessorOption) apply(n *newOptions) {
+	n.components.SpanProcessors = append(n.components.SpanProcessors, o.processor)
+}
+
+// WithSpanProcessor adds p to the tracer provider. Tracing is active with
+// only the injected processors when Config.Tracing.Enabled is false.
+// Config.Tracing.Sampler still applies; tail sampling does not.
+func WithSpanProcessor(p sdktrace.SpanProcessor) Option {
+	return spanProcessorOption{processor: p}
+}
+
+type spanExporterOption struct{ exporter sdktrace.SpanExporter }
+
+func (o spanExporterOption) apply(n *newOptions) {
+	n.components.SpanExporters = append(n.components.SpanExporters, o.exporter)
+}
+
+// WithSpanExporter adds e to the tracer provider behind a batch span processor
+// configured like the OTLP one (Tracing.BatchSize, Tracing.ExportInterval).
+// Tracing is active with only the injected exporters when
+// Config.Tracing.Enabled is false. Call [Ion.ForceFlush] to export
+// immediately, e.g. before asserting on a tracetest.InMemoryExporter.
+func WithSpanExporter(e sdktrace.SpanExporter) Option {
+	return spanExporterOption{exporter: e}
+}
+
+type logExporterOption struct{ exporter sdklog.Exporter }
+
+func (o logExporterOption) apply(n *newOptions) {
+	n.components.LogExporters = append(n.components.LogExporters, o.exporter)
+}
+
+// WithLogExporter adds e as a destination of the "otel" log output, behind a
+// batch processor configured like the OTLP one. The output is enabled for it
+// when Config.OTEL.Enabled is false, with OTEL.Level and the otel redaction
+// policy applying as usual.
+func WithLogExporter(e sdklog.Exporter) Option {
+	return logExporterOption{exporter: e}
+}
+
+type metricReaderOption struct{ reader sdkmetric.Reader }
+
+func (o metricReaderOption) apply(n *newOptions) {
+	n.components.MetricReaders = append(n.components.MetricReaders, o.reader)
+}
+
+// WithMetricReader adds r to the meter provider (e.g. a
+// sdkmetric.ManualReader in tests). Metrics are active with only the injected
+// readers when Config.Metrics.Enabled is false.
+func WithMetricReader(r sdkmetric.Reader) Option {
+	return metricReaderOption{reader: r}
+}
+
+type resourceOption struct{ resource *resource.Resource }
+
+func (o resourceOption) apply(n *newOptions) {
+	n.components.Resource = o.resource
+}
+
+// WithResource merges r over the resource ion builds for logs, traces, and
+// metrics (service name and version, host, OS, process, and the configured
+// attributes). Attributes in r win on conflict. The last WithResource applies.
+func WithResource(r *resource.Resource) Option {
+	return resourceOption{resource: r}
+}
+
+type contextExtractorOption struct{ extract ContextExtractor }
+
+func (o contextExtractorOption) apply(n *newOptions) {
+	if o.extract != nil {
+		n.extractors = append(n.extractors, o.extract)
+	}
+}
+
+// WithContextExtractor registers extract with the instance's loggers. Every
+// log call with a context other than context.Background() or context.TODO()
+// adds the fields it returns, after the built-in ones (trace_id, span_id,
+// request_id, user_id) and those attached with [ContextWithFields].
+// Extractors run in registration order on every such call, so keep them
+// cheap.
+func WithContextExtractor(extract ContextExtractor) Option {
+	return contextExtractorOption{extract: extract}
+}
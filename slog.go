@@ -0,0 +1,308 @@
+package ion
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/JupiterMetaLabs/ion/internal/core"
+)
+
+// SlogLevelCritical is the slog level that maps to [Logger.Critical].
+// Records at or above it are written at FATAL level; the process does not exit.
+const SlogLevelCritical = slog.LevelError + 4
+
+// NewSlogHandler returns a [slog.Handler] that writes to app's outputs, so
+// packages logging through log/slog share ion's levels, redaction, sampling,
+// and exporters:
+//
+//	slog.SetDefault(slog.New(ion.NewSlogHandler(app)))
+//
+// Trace and span IDs are taken from the record's context exactly as for
+// ion's own log methods. Levels map as: below Info to debug, below Warn to
+// info, below Error to warn, below [SlogLevelCritical] to error, and the
+// rest to critical.
+func NewSlogHandler(app *Ion) slog.Handler {
+	return &slogHandler{l: app.zapLogger}
+}
+
+// slogHandler adapts a zapLogger to slog.Handler.
+//
+// Groups are written as zap namespaces. Attributes added via WithAttrs are
+// kept as fields (not attached to the zap logger) so the context fields can
+// be written ahead of any namespace and stay top-level.
+type slogHandler struct {
+	l       *zapLogger
+	fields  []zap.Field // WithAttrs fields, with namespaces for groups
+	pending []string    // groups opened since the last WithAttrs
+}
+
+func slogToZapLevel(lvl slog.Level) zapcore.Level {
+	switch {
+	case lvl < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case lvl < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case lvl < slog.LevelError:
+		return zapcore.WarnLevel
+	case lvl < SlogLevelCritical:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.FatalLevel
+	}
+}
+
+// Enabled reports whether the logger would write a record at lvl.
+func (h *slogHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return h.l.enabled(slogToZapLevel(lvl))
+}
+
+// Handle writes the record.
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	lvl := slogToZapLevel(r.Level)
+	ce := h.l.zap.Check(lvl, r.Message)
+	if ce == nil {
+		return nil
+	}
+	if !r.Time.IsZero() {
+		ce.Time = r.Time
+	}
+	// Report the slog caller, not this handler.
+	if ce.Caller.Defined && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		ce.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+		ce.Caller.Function = frame.Function
+	}
+
+	fields := h.l.prepareFields(ctx, nil)
+	fields = append(fields, h.fields...)
+	var recordFields []zap.Field
+	if r.NumAttrs() > 0 {
+		recordFields = make([]zap.Field, 0, r.NumAttrs())
+		r.Attrs(func(a slog.Attr) bool {
+			recordFields = appendSlogAttr(recordFields, a)
+			return true
+		})
+	}
+	if len(recordFields) > 0 {
+		for _, g := range h.pending {
+			fields = append(fields, zap.Namespace(g))
+		}
+		fields = append(fields, recordFields...)
+	}
+	ce.Write(fields...)
+
+	if h.l.spanEvents.Enabled(lvl) {
+		eventFields := make([]zap.Field, 0, len(h.l.eventFields)+len(h.fields)+len(recordFields))
+		eventFields = append(append(append(eventFields, h.l.eventFields...), h.fields...), recordFields...)
+		h.l.spanEvents.Record(ctx, lvl, h.l.zap.Name(), r.Message, nil, eventFields)
+	}
+	return nil
+}
+
+// WithAttrs returns a handler that adds attrs to every record.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var added []zap.Field
+	for _, a := range attrs {
+		added = appendSlogAttr(added, a)
+	}
+	if len(added) == 0 {
+		return h
+	}
+	fields := make([]zap.Field, 0, len(h.fields)+len(h.pending)+len(added))
+	fields = append(fields, h.fields...)
+	for _, g := range h.pending {
+		fields = append(fields, zap.Namespace(g))
+	}
+	fields = append(fields, added...)
+	return &slogHandler{l: h.l, fields: fields}
+}
+
+// WithGroup returns a handler that nests later attributes under name.
+// Groups without attributes are omitted.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	pending := append(h.pending[:len(h.pending):len(h.pending)], name)
+	return &slogHandler{l: h.l, fields: h.fields, pending: pending}
+}
+
+// appendSlogAttr converts a to a zap field, following the slog.Handler rules:
+// values are resolved, empty attributes are ignored, and groups with an empty
+// key are inlined.
+func appendSlogAttr(fields []zap.Field, a slog.Attr) []zap.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return append(fields, zap.String(a.Key, a.Value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(a.Key, a.Value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(a.Key, a.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(a.Key, a.Value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(a.Key, a.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(a.Key, a.Value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(a.Key, a.Value.Time()))
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return fields
+		}
+		if a.Key == "" {
+			for _, ga := range attrs {
+				fields = appendSlogAttr(fields, ga)
+			}
+			return fields
+		}
+		return append(fields, zap.Object(a.Key, slogGroup(attrs)))
+	default:
+		if err, ok := a.Value.Any().(error); ok {
+			return append(fields, zap.NamedError(a.Key, err))
+		}
+		return append(fields, zap.Any(a.Key, a.Value.Any()))
+	}
+}
+
+// slogGroup encodes a slog group value as a nested object.
+type slogGroup []slog.Attr
+
+func (g slogGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range appendSlogAttr(nil, slog.Attr{Value: slog.GroupValue(g...)}) {
+		f.AddTo(enc)
+	}
+	return nil
+}
+
+// FromSlog returns a [Logger] that writes to sl, for code that takes an ion
+// Logger but is handed an *slog.Logger (e.g. by a host application).
+//
+// Fields become slog attributes; the error of Error and Critical is added as
+// "error" and Named names as "logger". Critical logs at [SlogLevelCritical].
+// SetLevel sets a minimum applied before sl's own handler; it starts at debug.
+// Sync and Shutdown are no-ops.
+func FromSlog(sl *slog.Logger) Logger {
+	level := new(atomic.Int64)
+	level.Store(int64(zapcore.DebugLevel))
+	return &slogLogger{sl: sl, level: level}
+}
+
+// slogLogger adapts an *slog.Logger to Logger.
+type slogLogger struct {
+	sl    *slog.Logger
+	name  string
+	level *atomic.Int64 // minimum zapcore.Level, shared with children
+}
+
+func (s *slogLogger) log(ctx context.Context, lvl slog.Level, msg string, err error, fields []Field) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if slogToZapLevel(lvl) < zapcore.Level(s.level.Load()) || !s.sl.Enabled(ctx, lvl) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip Callers, log, and the Logger method
+	r := slog.NewRecord(time.Now(), lvl, msg, pcs[0])
+	if s.name != "" {
+		r.AddAttrs(slog.String("logger", s.name))
+	}
+	for _, f := range fields {
+		r.AddAttrs(slogAttr(f))
+	}
+	if err != nil {
+		r.AddAttrs(slog.Any("error", err))
+	}
+	_ = s.sl.Handler().Handle(ctx, r)
+}
+
+// slogAttr converts an ion Field to a slog attribute.
+func slogAttr(f Field) slog.Attr {
+	switch f.Type {
+	case StringType:
+		return slog.String(f.Key, f.StringVal)
+	case Int64Type:
+		return slog.Int64(f.Key, f.Integer)
+	case Uint64Type:
+		if u, ok := f.Interface.(uint64); ok {
+			return slog.Uint64(f.Key, u)
+		}
+	case Float64Type:
+		return slog.Float64(f.Key, f.Float)
+	case BoolType:
+		return slog.Bool(f.Key, f.Integer == 1)
+	}
+	return slog.Any(f.Key, f.Interface)
+}
+
+// Debug logs a message at debug level.
+func (s *slogLogger) Debug(ctx context.Context, msg string, fields ...Field) {
+	s.log(ctx, slog.LevelDebug, msg, nil, fields)
+}
+
+// Info logs a message at info level.
+func (s *slogLogger) Info(ctx context.Context, msg string, fields ...Field) {
+	s.log(ctx, slog.LevelInfo, msg, nil, fields)
+}
+
+// Warn logs a message at warn level.
+func (s *slogLogger) Warn(ctx context.Context, msg string, fields ...Field) {
+	s.log(ctx, slog.LevelWarn, msg, nil, fields)
+}
+
+// Error logs a message at error level with an optional error.
+func (s *slogLogger) Error(ctx context.Context, msg string, err error, fields ...Field) {
+	s.log(ctx, slog.LevelError, msg, err, fields)
+}
+
+// Critical logs a message at SlogLevelCritical. It does not exit.
+func (s *slogLogger) Critical(ctx context.Context, msg string, err error, fields ...Field) {
+	s.log(ctx, SlogLevelCritical, msg, err, fields)
+}
+
+// With returns a child logger with additional fields attached.
+func (s *slogLogger) With(fields ...Field) Logger {
+	attrs := make([]any, 0, len(fields))
+	for _, f := range fields {
+		attrs = append(attrs, slogAttr(f))
+	}
+	return &slogLogger{sl: s.sl.With(attrs...), name: s.name, level: s.level}
+}
+
+// Named returns a named child logger. Names are joined with ".".
+func (s *slogLogger) Named(name string) Logger {
+	if s.name != "" {
+		name = s.name + "." + name
+	}
+	return &slogLogger{sl: s.sl, name: name, level: s.level}
+}
+
+// Sync is a no-op; slog handlers are unbuffered.
+func (s *slogLogger) Sync() error { return nil }
+
+// Shutdown is a no-op; the slog handler is owned by the caller.
+func (s *slogLogger) Shutdown(context.Context) error { return nil }
+
+// SetLevel sets the minimum level. Invalid levels are ignored.
+func (s *slogLogger) SetLevel(level string) {
+	if lvl, err := core.ParseLevel(level); err == nil {
+		s.level.Store(int64(lvl))
+	}
+}
+
+// GetLevel returns the minimum level set by SetLevel.
+func (s *slogLogger) GetLevel() string {
+	return zapcore.Level(s.level.Load()).String()
+}
@@ -0,0 +1,134 @@
+package ion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// testNoExit keeps Critical entries from exiting the test binary.
+type testNoExit struct{}
+
+func (testNoExit) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {}
+
+func newSlogTestApp(lvl zapcore.Level) (*Ion, *observer.ObservedLogs) {
+	obsCore, logs := observer.New(zapcore.DebugLevel)
+	app := &Ion{
+		zapLogger: &zapLogger{
+			zap:       zap.New(obsCore, zap.AddCaller(), zap.AddCallerSkip(1), zap.WithFatalHook(testNoExit{})),
+			config:    Default(),
+			atomicLvl: zap.NewAtomicLevelAt(lvl),
+		},
+	}
+	return app, logs
+}
+
+func TestSlogHandler_Levels(t *testing.T) {
+	app, logs := newSlogTestApp(zapcore.InfoLevel)
+	sl := slog.New(NewSlogHandler(app))
+	ctx := context.Background()
+
+	sl.Debug("dropped")
+	sl.Info("info")
+	sl.Warn("warn")
+	sl.Error("error")
+	sl.Log(ctx, SlogLevelCritical, "critical")
+
+	want := []zapcore.Level{zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel, zapcore.FatalLevel}
+	entries := logs.All()
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, e := range entries {
+		if e.Level != want[i] {
+			t.Errorf("entry %q level = %v, want %v", e.Message, e.Level, want[i])
+		}
+	}
+	if !strings.HasSuffix(entries[0].Caller.File, "slog_test.go") {
+		t.Errorf("caller = %q, want slog_test.go", entries[0].Caller.File)
+	}
+}
+
+func TestSlogHandler_AttrsAndGroups(t *testing.T) {
+	app, logs := newSlogTestApp(zapcore.DebugLevel)
+	sl := slog.New(NewSlogHandler(app)).With("chain", "mainnet").WithGroup("block").With("height", 42)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled,
+	}))
+
+	sl.InfoContext(ctx, "applied", "txs", 3, slog.Group("gas", "used", 21000), slog.Group("", "inline", true))
+
+	if logs.Len() != 1 {
+		t.Fatalf("got %d entries, want 1", logs.Len())
+	}
+	got := logs.All()[0].ContextMap()
+	if got["trace_id"] != traceID.String() || got["span_id"] != spanID.String() {
+		t.Errorf("trace fields = %v / %v, want top-level trace and span IDs", got["trace_id"], got["span_id"])
+	}
+	if got["chain"] != "mainnet" {
+		t.Errorf("chain = %v, want mainnet", got["chain"])
+	}
+	block, ok := got["block"].(map[string]any)
+	if !ok {
+		t.Fatalf("block = %#v, want a nested group", got["block"])
+	}
+	if block["height"] != int64(42) || block["txs"] != int64(3) || block["inline"] != true {
+		t.Errorf("block group = %v, want height, txs, and inline attrs", block)
+	}
+	if gas, _ := block["gas"].(map[string]any); gas["used"] != int64(21000) {
+		t.Errorf("block.gas = %v, want used=21000", block["gas"])
+	}
+}
+
+func TestSlogHandler_EmptyGroupOmitted(t *testing.T) {
+	app, logs := newSlogTestApp(zapcore.DebugLevel)
+	slog.New(NewSlogHandler(app)).WithGroup("empty").Info("no attrs")
+
+	if _, ok := logs.All()[0].ContextMap()["empty"]; ok {
+		t.Error("group without attributes was written")
+	}
+}
+
+func TestFromSlog(t *testing.T) {
+	var buf bytes.Buffer
+	l := FromSlog(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug, AddSource: true})))
+	ctx := context.Background()
+
+	l.Named("p2p").With(String("peer", "node-7")).Error(ctx, "dial failed", errors.New("timeout"), Int("attempt", 3))
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	want := map[string]any{"msg": "dial failed", "level": "ERROR", "logger": "p2p", "peer": "node-7", "attempt": float64(3), "error": "timeout"}
+	for k, v := range want {
+		if rec[k] != v {
+			t.Errorf("%s = %v, want %v", k, rec[k], v)
+		}
+	}
+	if src, _ := rec["source"].(map[string]any); !strings.HasSuffix(src["file"].(string), "slog_test.go") {
+		t.Errorf("source = %v, want slog_test.go", rec["source"])
+	}
+
+	buf.Reset()
+	l.SetLevel("warn")
+	l.Info(ctx, "filtered")
+	if buf.Len() != 0 {
+		t.Errorf("Info after SetLevel(warn) wrote %q", buf.String())
+	}
+	if got := l.GetLevel(); got != "warn" {
+		t.Errorf("GetLevel() = %q, want warn", got)
+	}
+}
@@ -0,0 +1,137 @@
+package ion
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/JupiterMetaLabs/ion/internal/core"
+)
+
+// RedirectStdLog routes the standard library's global logger (log.Printf and
+// friends) into l at the given level, under the name "stdlog". It returns a
+// func that restores the previous output, flags, and prefix.
+//
+// log.Fatal and log.Panic still exit or panic after the entry is written.
+// OpenTelemetry SDK errors are sent to ion's error handler (see
+// [SetErrorHandler]) instead, unless the application set its own.
+//
+//	undo, err := ion.RedirectStdLog(app, "info")
+//	if err != nil { ... }
+//	defer undo()
+func RedirectStdLog(l Logger, level string) (func(), error) {
+	lvl, err := core.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	// OTEL's default error handler writes to the standard logger, which
+	// would feed failed exports back into the exporters that failed.
+	routeOTELErrors()
+
+	flags, prefix, out := log.Flags(), log.Prefix(), log.Writer()
+	log.SetFlags(0)
+	log.SetPrefix("")
+	log.SetOutput(&stdLogWriter{l: l.Named("stdlog"), level: lvl})
+
+	return func() {
+		log.SetFlags(flags)
+		log.SetPrefix(prefix)
+		log.SetOutput(out)
+	}, nil
+}
+
+// stdLogWriter writes each log.Logger line as one entry.
+type stdLogWriter struct {
+	l     Logger
+	level zapcore.Level
+}
+
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	msg := string(bytes.TrimSuffix(p, []byte("\n")))
+	ctx := context.Background()
+	switch w.level {
+	case zapcore.DebugLevel:
+		w.l.Debug(ctx, msg)
+	case zapcore.InfoLevel:
+		w.l.Info(ctx, msg)
+	case zapcore.WarnLevel:
+		w.l.Warn(ctx, msg)
+	case zapcore.ErrorLevel:
+		w.l.Error(ctx, msg, nil)
+	default:
+		w.l.Critical(ctx, msg, nil)
+	}
+	return len(p), nil
+}
+
+// --- Internal diagnostics ---
+
+// errorHandler receives ion's internal diagnostics; nil means the default.
+var errorHandler atomic.Pointer[func(error)]
+
+// stderrLog is the default diagnostics output. It is separate from the
+// global logger so RedirectStdLog cannot loop diagnostics back into ion.
+var stderrLog = log.New(os.Stderr, "[ion] ", log.LstdFlags)
+
+// SetErrorHandler sets the process-wide handler for ion's internal
+// diagnostics (e.g. Tracer called with tracing disabled). Once [New] has run
+// with Config.SetGlobalProviders, or [RedirectStdLog] has been called, it
+// also receives OpenTelemetry SDK errors such as failed exports, unless the
+// application installed its own handler with otel.SetErrorHandler first. A nil handler restores the default, which
+// writes to stderr.
+//
+// To send them through a logger, use [LogErrors]:
+//
+//	ion.SetErrorHandler(ion.LogErrors(app))
+func SetErrorHandler(h func(error)) {
+	if h == nil {
+		errorHandler.Store(nil)
+		return
+	}
+	errorHandler.Store(&h)
+}
+
+// LogErrors returns an error handler that logs each error at warn level to l
+// under the name "ion". OTEL export failures logged this way are themselves
+// exported through OTEL when it is enabled, at most once per failed batch.
+func LogErrors(l Logger) func(error) {
+	named := l.Named("ion")
+	return func(err error) {
+		named.Warn(context.Background(), "internal error", Err(err))
+	}
+}
+
+// handleError reports an internal diagnostic to the configured handler.
+func handleError(err error) {
+	if h := errorHandler.Load(); h != nil {
+		(*h)(err)
+		return
+	}
+	stderrLog.Println(err)
+}
+
+var (
+	registerOTELErrorHandler sync.Once
+
+	// defaultOTELErrorHandler is the handler OTEL starts with; any other
+	// value was installed by the application and is left alone.
+	defaultOTELErrorHandler = otel.GetErrorHandler()
+)
+
+// routeOTELErrors sends OpenTelemetry SDK errors (failed exports, dropped
+// data) to handleError, unless the application set its own OTEL handler.
+func routeOTELErrors() {
+	registerOTELErrorHandler.Do(func() {
+		if otel.GetErrorHandler() != defaultOTELErrorHandler {
+			return
+		}
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(handleError))
+	})
+}
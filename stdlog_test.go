@@ -0,0 +1,138 @@
+package ion
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRedirectStdLog(t *testing.T) {
+	app, logs := newSlogTestApp(zapcore.DebugLevel)
+	prevFlags, prevOut := log.Flags(), log.Writer()
+
+	undo, err := RedirectStdLog(app, "warn")
+	if err != nil {
+		t.Fatalf("RedirectStdLog() error: %v", err)
+	}
+	log.Printf("dial %s failed", "peer-1")
+	undo()
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Message != "dial peer-1 failed" || e.Level != zapcore.WarnLevel || e.LoggerName != "stdlog" {
+		t.Errorf("entry = %q %v %q, want message without newline at warn from stdlog", e.Message, e.Level, e.LoggerName)
+	}
+	if log.Flags() != prevFlags || log.Writer() != prevOut {
+		t.Error("undo did not restore the standard logger's flags and output")
+	}
+
+	if _, err := RedirectStdLog(app, "loud"); err == nil {
+		t.Error("RedirectStdLog() with invalid level should fail")
+	}
+}
+
+func TestSetErrorHandler(t *testing.T) {
+	var got []error
+	SetErrorHandler(func(err error) { got = append(got, err) })
+	defer SetErrorHandler(nil)
+
+	handleError(errors.New("export failed"))
+	if len(got) != 1 || got[0].Error() != "export failed" {
+		t.Errorf("handler got %v, want [export failed]", got)
+	}
+
+	app, logs := newSlogTestApp(zapcore.DebugLevel)
+	SetErrorHandler(LogErrors(app))
+	handleError(errors.New("queue full"))
+	if logs.Len() != 1 || logs.All()[0].LoggerName != "ion" || logs.All()[0].ContextMap()["error"] != "queue full" {
+		t.Errorf("LogErrors entries = %v, want one warn from ion", logs.All())
+	}
+}
+
+type otelErrors struct{ got []error }
+
+func (h *otelErrors) Handle(err error) { h.got = append(h.got, err) }
+
+func TestNew_RoutesOTELErrorsOnlyWithGlobals(t *testing.T) {
+	restoreOTELGlobals(t)
+	t.Cleanup(func() {
+		otel.SetErrorHandler(defaultOTELErrorHandler)
+		registerOTELErrorHandler = sync.Once{}
+		SetErrorHandler(nil)
+	})
+	var ionGot []error
+	SetErrorHandler(func(err error) { ionGot = append(ionGot, err) })
+
+	newApp := func(globals bool) {
+		t.Helper()
+		registerOTELErrorHandler = sync.Once{}
+		cfg := Default()
+		cfg.Console.Enabled = false
+		cfg.SetGlobalProviders = globals
+		app, _, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New() error: %v", err)
+		}
+		_ = app.Shutdown(context.Background())
+	}
+
+	// An application's own OTEL handler is never replaced.
+	own := &otelErrors{}
+	otel.SetErrorHandler(own)
+	newApp(false)
+	newApp(true)
+	otel.Handle(errors.New("export failed"))
+	if len(own.got) != 1 || len(ionGot) != 0 {
+		t.Errorf("own handler got %v, ion got %v; want the error kept by the application's handler", own.got, ionGot)
+	}
+
+	// Without globals the default OTEL handler is left alone.
+	otel.SetErrorHandler(defaultOTELErrorHandler)
+	newApp(false)
+	if _, ok := otel.GetErrorHandler().(otel.ErrorHandlerFunc); ok {
+		t.Error("New() without SetGlobalProviders installed an OTEL error handler")
+	}
+
+	newApp(true)
+	otel.Handle(errors.New("export failed"))
+	if len(ionGot) != 1 {
+		t.Errorf("ion handler got %v, want the OTEL error routed with SetGlobalProviders", ionGot)
+	}
+}
+
+func TestRedirectStdLog_RoutesOTELErrors(t *testing.T) {
+	t.Cleanup(func() {
+		otel.SetErrorHandler(defaultOTELErrorHandler)
+		registerOTELErrorHandler = sync.Once{}
+		SetErrorHandler(nil)
+	})
+	if _, ok := otel.GetErrorHandler().(otel.ErrorHandlerFunc); ok {
+		otel.SetErrorHandler(defaultOTELErrorHandler)
+	}
+	registerOTELErrorHandler = sync.Once{}
+	var ionGot []error
+	SetErrorHandler(func(err error) { ionGot = append(ionGot, err) })
+
+	app, logs := newSlogTestApp(zapcore.DebugLevel)
+	undo, err := RedirectStdLog(app, "info")
+	if err != nil {
+		t.Fatalf("RedirectStdLog() error: %v", err)
+	}
+	if _, ok := otel.GetErrorHandler().(otel.ErrorHandlerFunc); !ok {
+		t.Error("RedirectStdLog() left OTEL errors on the standard logger")
+	}
+	otel.Handle(errors.New("export failed"))
+	undo()
+
+	if len(ionGot) != 1 || logs.Len() != 0 {
+		t.Errorf("ion handler got %v, stdlog logged %d entries; want the OTEL error kept out of the redirected logger", ionGot, logs.Len())
+	}
+}
@@ -3,7 +3,6 @@ package ion
 import (
 	"context"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -105,10 +104,9 @@ type otelTracer struct {
 	tracer trace.Tracer
 }
 
-// newOTELTracer creates a Tracer backed by the global OTel tracer provider.
-// The name identifies the instrumentation scope (e.g., "http.handler", "db.client").
-func newOTELTracer(name string) Tracer {
-	return &otelTracer{tracer: otel.Tracer(name)}
+// newOTELTracer wraps an OTel tracer from the instance's provider.
+func newOTELTracer(tracer trace.Tracer) Tracer {
+	return &otelTracer{tracer: tracer}
 }
 
 func (t *otelTracer) Start(ctx context.Context, spanName string, opts ...SpanOption) (context.Context, Span) {
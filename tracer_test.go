@@ -1,8 +1,16 @@
 package ion
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
 
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -37,3 +45,164 @@ func TestTracer_WithOTELOptions(t *testing.T) {
 		t.Errorf("Expected 1 otel option, got %d", len(so.otelOpts))
 	}
 }
+
+// newExportingApp returns an Ion whose spans go to an in-memory exporter.
+func newExportingApp(t *testing.T) (*Ion, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	cfg := Default()
+	cfg.Console.Enabled = false
+	cfg.Tracing.Sampler = "always"
+	app, _, err := New(cfg, WithSpanExporter(exp))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	t.Cleanup(func() { _ = app.Shutdown(context.Background()) })
+	return app, exp
+}
+
+func TestTracer_SpanTree(t *testing.T) {
+	app, exp := newExportingApp(t)
+	tracer := app.Tracer("consensus")
+
+	ctx, root := tracer.Start(context.Background(), "ProposeBlock",
+		WithSpanKind(trace.SpanKindServer),
+		WithAttributes(attribute.Int64("block.height", 100)),
+	)
+	linkCtx, linked := tracer.Start(context.Background(), "GossipReceived")
+	linked.End()
+
+	_, child := tracer.Start(ctx, "ValidateTxs", WithLinks(LinkFromContext(linkCtx)))
+	child.AddEvent("tx.rejected", attribute.String("tx.hash", "0xabc"))
+	child.RecordError(errors.New("bad nonce"))
+	child.SetStatus(StatusError, "1 invalid tx")
+	child.End()
+	root.End()
+
+	if err := app.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() error: %v", err)
+	}
+	spans := exp.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("exported %d spans, want 3", len(spans))
+	}
+	byName := make(map[string]tracetest.SpanStub, len(spans))
+	for _, s := range spans {
+		byName[s.Name] = s
+	}
+
+	rootStub, childStub := byName["ProposeBlock"], byName["ValidateTxs"]
+	if rootStub.SpanKind != trace.SpanKindServer {
+		t.Errorf("root kind = %v, want server", rootStub.SpanKind)
+	}
+	if len(rootStub.Attributes) != 1 || rootStub.Attributes[0] != attribute.Int64("block.height", 100) {
+		t.Errorf("root attributes = %v, want block.height=100", rootStub.Attributes)
+	}
+	if childStub.Parent.SpanID() != rootStub.SpanContext.SpanID() || childStub.SpanContext.TraceID() != rootStub.SpanContext.TraceID() {
+		t.Error("ValidateTxs is not a child of ProposeBlock")
+	}
+	if len(childStub.Links) != 1 || childStub.Links[0].SpanContext.SpanID() != byName["GossipReceived"].SpanContext.SpanID() {
+		t.Errorf("child links = %v, want a link to GossipReceived", childStub.Links)
+	}
+	if len(childStub.Events) != 2 || childStub.Events[0].Name != "tx.rejected" || childStub.Events[1].Name != "exception" {
+		t.Errorf("child events = %v, want tx.rejected then exception", childStub.Events)
+	}
+	if childStub.Status.Code != StatusError || childStub.Status.Description != "1 invalid tx" {
+		t.Errorf("child status = %+v, want Error with description", childStub.Status)
+	}
+	if svc, _ := rootStub.Resource.Set().Value("service.name"); svc.AsString() != Default().ServiceName {
+		t.Errorf("resource service.name = %q, want %q", svc.AsString(), Default().ServiceName)
+	}
+}
+
+func TestNew_WithResource(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	cfg := Default()
+	cfg.Console.Enabled = false
+	cfg.Tracing.Sampler = "always"
+	app, _, err := New(cfg, WithSpanExporter(exp), WithResource(resource.NewSchemaless(
+		attribute.String("k8s.pod.name", "bridge-0"),
+		attribute.String("service.version", "2.0.0"),
+	)))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = app.Shutdown(context.Background()) }()
+
+	_, span := app.Tracer("test").Start(context.Background(), "op")
+	span.End()
+	if err := app.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() error: %v", err)
+	}
+	spans := exp.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(spans))
+	}
+	set := spans[0].Resource.Set()
+	want := map[attribute.Key]string{
+		"service.name":    cfg.ServiceName,
+		"service.version": "2.0.0",
+		"k8s.pod.name":    "bridge-0",
+	}
+	for k, v := range want {
+		if got, _ := set.Value(k); got.AsString() != v {
+			t.Errorf("resource[%s] = %q, want %q", k, got.AsString(), v)
+		}
+	}
+}
+
+// recordingLogExporter keeps exported log records.
+type recordingLogExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+func (e *recordingLogExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingLogExporter) ForceFlush(context.Context) error { return nil }
+
+func TestNew_WithLogExporter(t *testing.T) {
+	exp := &recordingLogExporter{}
+	cfg := Default()
+	cfg.Console.Enabled = false
+	cfg.Tracing.Sampler = "always"
+	app, _, err := New(cfg, WithLogExporter(exp), WithSpanExporter(tracetest.NewInMemoryExporter()))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = app.Shutdown(context.Background()) }()
+
+	ctx, span := app.Tracer("test").Start(context.Background(), "op")
+	app.Info(ctx, "block applied", Int("height", 7))
+	span.End()
+
+	if err := app.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() error: %v", err)
+	}
+	exp.mu.Lock()
+	defer exp.mu.Unlock()
+	if len(exp.records) != 1 {
+		t.Fatalf("exported %d log records, want 1", len(exp.records))
+	}
+	r := exp.records[0]
+	if r.Body().AsString() != "block applied" {
+		t.Errorf("body = %q, want %q", r.Body().AsString(), "block applied")
+	}
+	var traceID string
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		if kv.Key == "trace_id" {
+			traceID = kv.Value.AsString()
+		}
+		return true
+	})
+	if want := trace.SpanContextFromContext(ctx).TraceID().String(); traceID != want {
+		t.Errorf("trace_id = %q, want %q", traceID, want)
+	}
+}